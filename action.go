@@ -0,0 +1,35 @@
+package midjourney
+
+import (
+	"context"
+	"net/url"
+)
+
+// actionResponse is the response of endpoints which perform an action on
+// behalf of the current user, like ranking a job.
+type actionResponse struct {
+	Success bool   `json:"success,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// action makes a request to an endpoint which performs an action, returning a
+// *ResponseError if the response contains an error message.
+func (c *Client) action(
+	ctx context.Context,
+	method string,
+	path string,
+	params url.Values,
+	body any,
+) error {
+	resp := &actionResponse{}
+
+	err := c.API.Request(ctx, method, path, params, body, resp)
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return &ResponseError{Message: resp.Error}
+	}
+
+	return nil
+}
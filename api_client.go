@@ -22,6 +22,12 @@ type APIClient struct {
 	AuthToken  string
 	UserAgent  string
 	Logger     zerolog.Logger
+
+	// StrictMode controls how unknown fields in responses are reported.
+	StrictMode StrictMode
+
+	// SubmitMode enables Client.Submit, which enqueues new jobs.
+	SubmitMode bool
 }
 
 func NewAPI(options ...Option) (*APIClient, error) {
@@ -141,6 +147,30 @@ func (ac *APIClient) Request(
 		return respErr
 	}
 
+	return ac.checkUnknownFields(u, result)
+}
+
+func (ac *APIClient) checkUnknownFields(u *url.URL, result any) error {
+	if ac.StrictMode == StrictModeOff {
+		return nil
+	}
+
+	fields := UnknownFields(result)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	if ac.StrictMode == StrictModeError {
+		return fmt.Errorf(
+			"%w: %s: %s", ErrUnknownFields, u.Path, strings.Join(fields, ", "),
+		)
+	}
+
+	ac.Logger.Warn().
+		Str("url", u.String()).
+		Strs("fields", fields).
+		Msg("unknown fields in response")
+
 	return nil
 }
 
@@ -0,0 +1,26 @@
+package midjourney
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseAspect parses an aspect ratio like "16:9" into a width/height ratio. It
+// returns zero if s is not a valid aspect ratio.
+func ParseAspect(s string) float64 {
+	w, h, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return 0
+	}
+
+	wf, err := strconv.ParseFloat(strings.TrimSpace(w), 64)
+	if err != nil || wf <= 0 {
+		return 0
+	}
+	hf, err := strconv.ParseFloat(strings.TrimSpace(h), 64)
+	if err != nil || hf <= 0 {
+		return 0
+	}
+
+	return wf / hf
+}
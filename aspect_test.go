@@ -0,0 +1,27 @@
+package midjourney
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAspect(t *testing.T) {
+	tests := []struct {
+		s    string
+		want float64
+	}{
+		{s: "", want: 0},
+		{s: "1:1", want: 1},
+		{s: "16:10", want: 1.6},
+		{s: " 3 : 2 ", want: 1.5},
+		{s: "0:1", want: 0},
+		{s: "foo", want: 0},
+		{s: "a:b", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.InDelta(t, tt.want, ParseAspect(tt.s), 0.0001)
+		})
+	}
+}
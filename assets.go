@@ -0,0 +1,298 @@
+package midjourney
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	DefaultGalleryHost = "mj-gallery.com"
+	DefaultCDNHost     = "cdn.midjourney.com"
+	DefaultMediaHost   = "i.mj.run"
+	DefaultStorageHost = "storage.googleapis.com"
+)
+
+// gridImageCount is the number of images in a grid job when neither its
+// ImagePaths nor its batch size are known.
+const gridImageCount = 4
+
+// DefaultAssets is the Assets resolver used by Job and Word URL methods.
+var DefaultAssets = &Assets{}
+
+type AssetKind string
+
+const (
+	AssetGrid           AssetKind = "grid"
+	AssetImage          AssetKind = "image"
+	AssetGridThumbnail  AssetKind = "grid_thumbnail"
+	AssetImageThumbnail AssetKind = "image_thumbnail"
+	AssetVideo          AssetKind = "video"
+	AssetStoragePath    AssetKind = "storage_path"
+)
+
+type ImageFormat string
+
+const (
+	ImageFormatPNG  ImageFormat = "png"
+	ImageFormatWebP ImageFormat = "webp"
+)
+
+var (
+	ThumbnailSizes = []ThumbnailSize{
+		ThumbnailSizeSmall,
+		ThumbnailSizeMedium,
+		ThumbnailSizeLarge,
+	}
+	ThumbnailFormats = []ImageFormat{ImageFormatWebP, ImageFormatPNG}
+)
+
+// Asset is a single file belonging to a job.
+type Asset struct {
+	Kind AssetKind
+	URL  string
+
+	// Index is the zero-based index of the image within the job. It is zero
+	// for grids and videos.
+	Index int
+
+	// Size and Format are only set for thumbnails.
+	Size   ThumbnailSize
+	Format ImageFormat
+
+	// StatusCode and Exists are set by Assets.Verify.
+	StatusCode int
+	Exists     bool
+}
+
+// Assets resolves URLs of images, thumbnails and videos of jobs. The zero value
+// uses the default hosts.
+type Assets struct {
+	// GalleryHost serves full size images.
+	GalleryHost string
+
+	// CDNHost serves thumbnails.
+	CDNHost string
+
+	// MediaHost serves videos and word images.
+	MediaHost string
+
+	// StorageHost replaces the host of job ImagePaths served from
+	// DefaultStorageHost.
+	StorageHost string
+
+	// HTTPClient is used by Verify. Defaults to http.DefaultClient.
+	HTTPClient HTTPClient
+
+	// Concurrency is the maximum number of requests made by Verify at once.
+	// Defaults to DefaultConcurrency.
+	Concurrency int
+}
+
+func (a *Assets) host(host, fallback string) string {
+	if host == "" {
+		return fallback
+	}
+
+	return host
+}
+
+// GridURL returns the URL of the full size grid image of the job.
+func (a *Assets) GridURL(j *Job) string {
+	return fmt.Sprintf("https://%s/%s/grid_0.png",
+		a.host(a.GalleryHost, DefaultGalleryHost), j.ID,
+	)
+}
+
+// ImageURL returns the URL of the full size image at the given index of the
+// job. Upscale jobs have a single image at index 0.
+func (a *Assets) ImageURL(j *Job, index int) string {
+	return fmt.Sprintf("https://%s/%s/0_%d.png",
+		a.host(a.GalleryHost, DefaultGalleryHost), j.ID, index,
+	)
+}
+
+// GridThumbnailURL returns the URL of a thumbnail of the job's grid image.
+func (a *Assets) GridThumbnailURL(
+	j *Job,
+	size ThumbnailSize,
+	format ImageFormat,
+) string {
+	return fmt.Sprintf("https://%s/%s/grid_0_%d_N.%s",
+		a.host(a.CDNHost, DefaultCDNHost), j.ID, size, a.format(format),
+	)
+}
+
+// ImageThumbnailURL returns the URL of a thumbnail of the image at the given
+// index of the job.
+func (a *Assets) ImageThumbnailURL(
+	j *Job,
+	index int,
+	size ThumbnailSize,
+	format ImageFormat,
+) string {
+	return fmt.Sprintf("https://%s/%s/0_%d_%d_N.%s",
+		a.host(a.CDNHost, DefaultCDNHost), j.ID, index, size, a.format(format),
+	)
+}
+
+func (a *Assets) format(f ImageFormat) ImageFormat {
+	if f == "" {
+		return ImageFormatWebP
+	}
+
+	return f
+}
+
+// VideoURL returns the URL of the video of a grid job, or an empty string for
+// other jobs. Videos only exist for jobs created with the video parameter.
+func (a *Assets) VideoURL(j *Job) string {
+	if j.Type != JobTypeGrid {
+		return ""
+	}
+
+	return fmt.Sprintf("https://%s/%s/video.mp4",
+		a.host(a.MediaHost, DefaultMediaHost), j.ID,
+	)
+}
+
+// WordImageURL returns the URL of the image of the given word.
+func (a *Assets) WordImageURL(w *Word) string {
+	return fmt.Sprintf("https://%s/%s/0_0.png",
+		a.host(a.MediaHost, DefaultMediaHost), w.ImageID,
+	)
+}
+
+// StoragePaths returns the job's ImagePaths, with the host replaced by
+// StorageHost when set.
+func (a *Assets) StoragePaths(j *Job) []string {
+	paths := make([]string, 0, len(j.ImagePaths))
+	for _, p := range j.ImagePaths {
+		if a.StorageHost != "" {
+			if u, err := url.Parse(p); err == nil &&
+				u.Host == DefaultStorageHost {
+				u.Host = a.StorageHost
+				p = u.String()
+			}
+		}
+		paths = append(paths, p)
+	}
+
+	return paths
+}
+
+// ImageCount returns the number of images of the job, from its ImagePaths if
+// known, or otherwise its type and batch size.
+func (a *Assets) ImageCount(j *Job) int {
+	switch {
+	case len(j.ImagePaths) > 0:
+		return len(j.ImagePaths)
+	case j.Type == JobTypeUpscale:
+		return 1
+	case j.Event != nil && j.Event.BatchSize.Int() > 0:
+		return j.Event.BatchSize.Int()
+	}
+
+	return gridImageCount
+}
+
+// All returns every asset which may exist for the job: grid, images, all
+// thumbnail sizes and formats, video, and storage paths.
+func (a *Assets) All(j *Job) []*Asset {
+	assets := []*Asset{}
+	isGrid := j.Type != JobTypeUpscale
+
+	if isGrid {
+		assets = append(assets, &Asset{Kind: AssetGrid, URL: a.GridURL(j)})
+	}
+	for i := 0; i < a.ImageCount(j); i++ {
+		assets = append(assets, &Asset{
+			Kind:  AssetImage,
+			URL:   a.ImageURL(j, i),
+			Index: i,
+		})
+	}
+
+	for _, format := range ThumbnailFormats {
+		for _, size := range ThumbnailSizes {
+			if isGrid {
+				assets = append(assets, &Asset{
+					Kind:   AssetGridThumbnail,
+					URL:    a.GridThumbnailURL(j, size, format),
+					Size:   size,
+					Format: format,
+				})
+			}
+			for i := 0; i < a.ImageCount(j); i++ {
+				assets = append(assets, &Asset{
+					Kind:   AssetImageThumbnail,
+					URL:    a.ImageThumbnailURL(j, i, size, format),
+					Index:  i,
+					Size:   size,
+					Format: format,
+				})
+			}
+		}
+	}
+
+	if u := a.VideoURL(j); u != "" {
+		assets = append(assets, &Asset{Kind: AssetVideo, URL: u})
+	}
+	for i, p := range a.StoragePaths(j) {
+		assets = append(assets, &Asset{
+			Kind:  AssetStoragePath,
+			URL:   p,
+			Index: i,
+		})
+	}
+
+	return assets
+}
+
+// Verify checks which of the job's assets exist with HEAD requests, and
+// returns all assets with StatusCode and Exists set. Assets which could not be
+// checked are returned in a *BulkError keyed by URL.
+func (a *Assets) Verify(ctx context.Context, j *Job) ([]*Asset, error) {
+	assets := a.All(j)
+	byURL := make(map[string][]*Asset, len(assets))
+	urls := make([]string, 0, len(assets))
+	for _, as := range assets {
+		if _, ok := byURL[as.URL]; !ok {
+			urls = append(urls, as.URL)
+		}
+		byURL[as.URL] = append(byURL[as.URL], as)
+	}
+
+	client := a.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	err := runBulk(ctx, urls, a.Concurrency,
+		func(ctx context.Context, u string) error {
+			req, err := http.NewRequestWithContext(
+				ctx, http.MethodHead, u, nil,
+			)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+
+			for _, as := range byURL[u] {
+				as.StatusCode = resp.StatusCode
+				as.Exists = resp.StatusCode >= 200 &&
+					resp.StatusCode < 300
+			}
+
+			return nil
+		},
+	)
+
+	return assets, err
+}
@@ -0,0 +1,241 @@
+package midjourney
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssets_URLs(t *testing.T) {
+	j := &Job{
+		ID:   "a3052616-372b-42a1-a72b-eb86fa0be633",
+		Type: JobTypeGrid,
+	}
+	custom := &Assets{
+		GalleryHost: "gallery.example.com",
+		CDNHost:     "cdn.example.com",
+		MediaHost:   "media.example.com",
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "grid",
+			got:  j.MainImageURL(),
+			want: "https://mj-gallery.com/" + j.ID + "/grid_0.png",
+		},
+		{
+			name: "custom grid",
+			got:  custom.GridURL(j),
+			want: "https://gallery.example.com/" + j.ID + "/grid_0.png",
+		},
+		{
+			name: "image",
+			got:  custom.ImageURL(j, 2),
+			want: "https://gallery.example.com/" + j.ID + "/0_2.png",
+		},
+		{
+			name: "grid thumbnail",
+			got:  j.ThumbnailURL(ThumbnailSizeMedium),
+			want: "https://cdn.midjourney.com/" + j.ID +
+				"/grid_0_384_N.webp",
+		},
+		{
+			name: "image thumbnail",
+			got: custom.ImageThumbnailURL(
+				j, 1, ThumbnailSizeSmall, ImageFormatPNG,
+			),
+			want: "https://cdn.example.com/" + j.ID + "/0_1_128_N.png",
+		},
+		{
+			name: "video",
+			got:  custom.VideoURL(j),
+			want: "https://media.example.com/" + j.ID + "/video.mp4",
+		},
+		{
+			name: "word",
+			got:  (&Word{ImageID: j.ID}).ImageURL(),
+			want: "https://i.mj.run/" + j.ID + "/0_0.png",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got)
+		})
+	}
+}
+
+func TestAssets_StoragePaths(t *testing.T) {
+	j := &Job{ImagePaths: []string{
+		"https://storage.googleapis.com/dream-machines-output/a/0_0.png",
+		"https://other.example.com/a/0_1.png",
+	}}
+	a := &Assets{StorageHost: "storage.example.com"}
+
+	assert.Equal(t, []string{
+		"https://storage.example.com/dream-machines-output/a/0_0.png",
+		"https://other.example.com/a/0_1.png",
+	}, a.StoragePaths(j))
+	assert.Equal(t, j.ImagePaths, DefaultAssets.StoragePaths(j))
+}
+
+func TestAssets_ImageCount(t *testing.T) {
+	tests := []struct {
+		name string
+		job  *Job
+		want int
+	}{
+		{
+			name: "grid",
+			job:  &Job{Type: JobTypeGrid},
+			want: 4,
+		},
+		{
+			name: "grid with batch size",
+			job: &Job{
+				Type:  JobTypeGrid,
+				Event: &Event{BatchSize: NewFlexInt(2)},
+			},
+			want: 2,
+		},
+		{
+			name: "grid with zero batch size",
+			job: &Job{
+				Type:  JobTypeGrid,
+				Event: &Event{BatchSize: NewFlexInt(0)},
+			},
+			want: 4,
+		},
+		{
+			name: "upscale",
+			job: &Job{
+				Type:  JobTypeUpscale,
+				Event: &Event{BatchSize: NewFlexInt(4)},
+			},
+			want: 1,
+		},
+		{
+			name: "image paths",
+			job: &Job{
+				Type:       JobTypeGrid,
+				ImagePaths: []string{"a", "b", "c"},
+				Event:      &Event{BatchSize: NewFlexInt(4)},
+			},
+			want: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DefaultAssets.ImageCount(tt.job))
+		})
+	}
+}
+
+func TestAssets_All(t *testing.T) {
+	countKinds := func(assets []*Asset) map[AssetKind]int {
+		kinds := map[AssetKind]int{}
+		for _, as := range assets {
+			kinds[as.Kind]++
+		}
+
+		return kinds
+	}
+	thumbs := len(ThumbnailSizes) * len(ThumbnailFormats)
+
+	grid := DefaultAssets.All(&Job{ID: "g", Type: JobTypeGrid})
+	assert.Equal(t, map[AssetKind]int{
+		AssetGrid:           1,
+		AssetImage:          4,
+		AssetGridThumbnail:  thumbs,
+		AssetImageThumbnail: 4 * thumbs,
+		AssetVideo:          1,
+	}, countKinds(grid))
+
+	upscale := DefaultAssets.All(&Job{
+		ID:         "u",
+		Type:       JobTypeUpscale,
+		ImagePaths: []string{"https://storage.googleapis.com/u/0_0.png"},
+	})
+	assert.Equal(t, map[AssetKind]int{
+		AssetImage:          1,
+		AssetImageThumbnail: thumbs,
+		AssetStoragePath:    1,
+	}, countKinds(upscale))
+}
+
+type fakeHeadClient struct {
+	mux      sync.Mutex
+	status   map[string]int
+	requests []string
+}
+
+func (c *fakeHeadClient) Do(req *http.Request) (*http.Response, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.requests = append(c.requests, req.Method+" "+req.URL.String())
+	if strings.HasSuffix(req.URL.Path, ".mp4") {
+		return nil, errors.New("connection reset")
+	}
+
+	status, ok := c.status[req.URL.Path]
+	if !ok {
+		status = http.StatusNotFound
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+func TestAssets_Verify(t *testing.T) {
+	client := &fakeHeadClient{status: map[string]int{
+		"/g/grid_0.png":        http.StatusOK,
+		"/g/0_1.png":           http.StatusOK,
+		"/g/grid_0_640_N.webp": http.StatusOK,
+		"/g/0_3_128_N.png":     http.StatusForbidden,
+		"/g/0_0_384_N.webp":    http.StatusNoContent,
+	}}
+	a := &Assets{HTTPClient: client, Concurrency: 2}
+	j := &Job{ID: "g", Type: JobTypeGrid}
+
+	assets, err := a.Verify(context.Background(), j)
+
+	var be *BulkError
+	require.True(t, errors.As(err, &be))
+	assert.Equal(t, []string{a.VideoURL(j)}, be.IDs())
+
+	require.Len(t, assets, len(a.All(j)))
+	require.Len(t, client.requests, len(assets))
+	for _, r := range client.requests {
+		assert.True(t, strings.HasPrefix(r, "HEAD "), r)
+	}
+
+	existing := []string{}
+	for _, as := range assets {
+		if as.Exists {
+			existing = append(existing, as.URL)
+		}
+		if as.Kind == AssetImageThumbnail && as.Index == 3 &&
+			as.Format == ImageFormatPNG && as.Size == ThumbnailSizeSmall {
+			assert.Equal(t, http.StatusForbidden, as.StatusCode)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		a.GridURL(j),
+		a.ImageURL(j, 1),
+		a.GridThumbnailURL(j, ThumbnailSizeLarge, ImageFormatWebP),
+		a.ImageThumbnailURL(j, 0, ThumbnailSizeMedium, ImageFormatWebP),
+	}, existing)
+}
@@ -0,0 +1,139 @@
+package midjourney
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultConcurrency is the number of concurrent requests made by bulk
+// operations when no concurrency is given.
+const DefaultConcurrency = 4
+
+// BulkError is returned by bulk operations when one or more items failed. It
+// matches any error matched by one of its item errors with errors.Is and
+// errors.As.
+type BulkError struct {
+	// Errors maps IDs of failed items to their error.
+	Errors map[string]error
+
+	// Succeeded are the sorted IDs of all items which did not fail.
+	Succeeded []string
+}
+
+func (e *BulkError) Error() string {
+	ids := e.IDs()
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", id, e.Errors[id]))
+	}
+
+	return fmt.Sprintf(
+		"%s: %d of %d failed: %s",
+		Err, len(ids), len(ids)+len(e.Succeeded), strings.Join(msgs, "; "),
+	)
+}
+
+// IDs returns the sorted IDs of all failed items.
+func (e *BulkError) IDs() []string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func (e *BulkError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *BulkError) As(target any) bool {
+	for _, id := range e.IDs() {
+		if errors.As(e.Errors[id], target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// runBulk calls fn for each of the given IDs, with at most concurrency calls
+// in flight at once. It returns a *BulkError if any call failed. IDs not yet
+// processed when ctx is done fail with the context's error.
+func runBulk(
+	ctx context.Context,
+	ids []string,
+	concurrency int,
+	fn func(ctx context.Context, id string) error,
+) error {
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mux  sync.Mutex
+		errs = map[string]error{}
+		sem  = make(chan struct{}, concurrency)
+	)
+
+	fail := func(id string, err error) {
+		mux.Lock()
+		errs[id] = err
+		mux.Unlock()
+	}
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			fail(id, err)
+
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			fail(id, ctx.Err())
+
+			continue
+		}
+
+		wg.Add(1)
+		go func(id string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := fn(ctx, id); err != nil {
+				fail(id, err)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	succeeded := []string{}
+	for _, id := range ids {
+		if _, ok := errs[id]; !ok {
+			succeeded = append(succeeded, id)
+		}
+	}
+	sort.Strings(succeeded)
+
+	return &BulkError{Errors: errs, Succeeded: succeeded}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sync"
 )
 
 var (
@@ -27,6 +28,14 @@ var (
 
 type Client struct {
 	API *APIClient
+
+	// me caches the ID of the authenticated user for the auth token it was
+	// fetched with.
+	me struct {
+		sync.Mutex
+		authToken string
+		userID    string
+	}
 }
 
 func New(options ...Option) (*Client, error) {
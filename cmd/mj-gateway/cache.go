@@ -0,0 +1,90 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// cache is a simple in-memory TTL cache of response bodies.
+type cache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+	mux        sync.Mutex
+	entries    map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+func newCache(ttl time.Duration, maxEntries int) *cache {
+	return &cache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    map[string]*cacheEntry{},
+	}
+}
+
+func (c *cache) Get(key string) ([]byte, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+
+		return nil, false
+	}
+
+	return e.value, true
+}
+
+func (c *cache) Set(key string, value []byte) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= c.maxEntries {
+		c.evict(now)
+	}
+
+	c.entries[key] = &cacheEntry{value: value, expires: now.Add(c.ttl)}
+}
+
+// evict removes all expired entries, or if none have expired, the entry
+// closest to expiring.
+func (c *cache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+
+	removed := false
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+			removed = true
+
+			continue
+		}
+		if oldestKey == "" || e.expires.Before(oldest) {
+			oldestKey, oldest = k, e.expires
+		}
+	}
+
+	if !removed && oldestKey != "" {
+		delete(c.entries, oldestKey)
+	}
+}
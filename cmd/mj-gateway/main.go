@@ -0,0 +1,118 @@
+// Command mj-gateway is a read-only HTTP gateway in front of the MidJourney
+// API, allowing services to access MidJourney data without holding a session
+// token themselves.
+//
+// The MidJourney auth token is read from the MIDJOURNEY_AUTH_TOKEN environment
+// variable, and gateway API keys from MJ_GATEWAY_API_KEYS as a comma separated
+// list. Clients authenticate with either a "X-API-Key" header, or an
+// "Authorization: Bearer <key>" header.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/rs/zerolog"
+)
+
+func main() {
+	logger := zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+	err := run(logger, os.Args[1:])
+	if err != nil {
+		logger.Fatal().Err(err).Msg("exiting")
+	}
+}
+
+func run(logger zerolog.Logger, args []string) error {
+	fs := flag.NewFlagSet("mj-gateway", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	apiKeys := fs.String(
+		"api-keys", os.Getenv("MJ_GATEWAY_API_KEYS"),
+		"comma separated list of accepted API keys",
+	)
+	cacheTTL := fs.Duration("cache-ttl", time.Minute, "response cache TTL")
+	rate := fs.Float64("rate", 5, "requests per second allowed per API key")
+	burst := fs.Int("burst", 20, "request burst size allowed per API key")
+	origins := fs.String(
+		"cors-origins", "", "comma separated list of allowed CORS origins",
+	)
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	opts := []midjourney.Option{
+		midjourney.WithAuthToken(os.Getenv("MIDJOURNEY_AUTH_TOKEN")),
+		midjourney.WithLogger(logger),
+	}
+	if u := os.Getenv("MIDJOURNEY_API_URL"); u != "" {
+		opts = append(opts, midjourney.WithAPIURL(u))
+	}
+
+	client, err := midjourney.New(opts...)
+	if err != nil {
+		return err
+	}
+
+	keys := splitList(*apiKeys)
+	if len(keys) == 0 {
+		return errors.New("at least one API key is required")
+	}
+
+	srv := &http.Server{
+		Addr: *addr,
+		Handler: newServer(&config{
+			Backend:     client,
+			APIKeys:     keys,
+			CacheTTL:    *cacheTTL,
+			Rate:        *rate,
+			Burst:       *burst,
+			CORSOrigins: splitList(*origins),
+			Logger:      logger,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(
+			context.Background(), 10*time.Second,
+		)
+		defer cancel()
+
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info().Str("addr", *addr).Msg("listening")
+
+	err = srv.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+
+	return list
+}
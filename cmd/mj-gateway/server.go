@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/rs/zerolog"
+)
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+// backend is the subset of midjourney.Client used by the gateway.
+type backend interface {
+	RecentJobs(
+		ctx context.Context,
+		q *midjourney.RecentJobsQuery,
+	) (*midjourney.RecentJobs, error)
+	GetJob(ctx context.Context, jobID string) (*midjourney.Job, error)
+	ArchiveDay(ctx context.Context, date time.Time) ([]string, error)
+	Collections(
+		ctx context.Context,
+		q *midjourney.CollectionsQuery,
+	) ([]*midjourney.Collection, error)
+	Words(ctx context.Context, q *midjourney.WordsQuery) (
+		[]*midjourney.Word, error,
+	)
+}
+
+type config struct {
+	Backend     backend
+	APIKeys     []string
+	CacheTTL    time.Duration
+	Rate        float64
+	Burst       int
+	CORSOrigins []string
+	Logger      zerolog.Logger
+}
+
+// requestError is returned by handlers when the request itself is invalid.
+type requestError struct {
+	msg string
+}
+
+func (e *requestError) Error() string {
+	return e.msg
+}
+
+type server struct {
+	backend backend
+	logger  zerolog.Logger
+	cache   *cache
+}
+
+func newServer(cfg *config) http.Handler {
+	s := &server{
+		backend: cfg.Backend,
+		logger:  cfg.Logger,
+		cache:   newCache(cfg.CacheTTL, 1000),
+	}
+
+	api := http.NewServeMux()
+	api.HandleFunc("/jobs", s.cached(s.handleJobs))
+	api.HandleFunc("/jobs/", s.cached(s.handleJob))
+	api.HandleFunc("/archive/", s.cached(s.handleArchive))
+	api.HandleFunc("/collections", s.cached(s.handleCollections))
+	api.HandleFunc("/words", s.cached(s.handleWords))
+
+	authed := requireAPIKey(cfg.APIKeys,
+		rateLimit(newLimiter(cfg.Rate, cfg.Burst), api),
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.json", handleOpenAPI)
+	mux.Handle("/", authed)
+
+	return cors(cfg.CORSOrigins, logRequests(cfg.Logger, mux))
+}
+
+type handlerFunc func(r *http.Request) (any, error)
+
+// cached wraps a handlerFunc, writing its result as JSON and caching
+// successful responses by request URL.
+func (s *server) cached(h handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, http.StatusMethodNotAllowed,
+				errors.New("method not allowed"),
+			)
+
+			return
+		}
+
+		key := r.URL.Path + "?" + r.URL.Query().Encode()
+		if b, ok := s.cache.Get(key); ok {
+			w.Header().Set("X-Cache", "HIT")
+			writeJSONBytes(w, http.StatusOK, b)
+
+			return
+		}
+
+		v, err := h(r)
+		if err != nil {
+			s.logger.Debug().Err(err).Str("path", r.URL.Path).Msg("error")
+			writeError(w, errorStatus(err), err)
+
+			return
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+
+			return
+		}
+
+		s.cache.Set(key, b)
+		w.Header().Set("X-Cache", "MISS")
+		writeJSONBytes(w, http.StatusOK, b)
+	}
+}
+
+type jobsResponse struct {
+	Page int               `json:"page"`
+	Jobs []*midjourney.Job `json:"jobs"`
+}
+
+func (s *server) handleJobs(r *http.Request) (any, error) {
+	q, err := midjourney.ParseRecentJobsQuery(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	rj, err := s.backend.RecentJobs(r.Context(), q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jobsResponse{Page: rj.Page, Jobs: rj.Jobs}, nil
+}
+
+func (s *server) handleJob(r *http.Request) (any, error) {
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/jobs/"), "/")
+	if id == "" || strings.Contains(id, "/") {
+		return nil, midjourney.ErrNotFound
+	}
+
+	return s.backend.GetJob(r.Context(), id)
+}
+
+type archiveResponse struct {
+	Date   string   `json:"date"`
+	JobIDs []string `json:"job_ids"`
+}
+
+func (s *server) handleArchive(r *http.Request) (any, error) {
+	date := strings.Trim(strings.TrimPrefix(r.URL.Path, "/archive/"), "/")
+
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, badRequest("date must be formatted as YYYY-MM-DD")
+	}
+
+	ids, err := s.backend.ArchiveDay(r.Context(), t)
+	if err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+
+	return &archiveResponse{Date: date, JobIDs: ids}, nil
+}
+
+func (s *server) handleCollections(r *http.Request) (any, error) {
+	params := r.URL.Query()
+	q := &midjourney.CollectionsQuery{
+		UserID:       params.Get("user_id"),
+		CollectionID: params.Get("collection_id"),
+	}
+	if q.UserID == "" && q.CollectionID == "" {
+		return nil, badRequest("user_id or collection_id is required")
+	}
+
+	cols, err := s.backend.Collections(r.Context(), q)
+	if err != nil {
+		return nil, err
+	}
+	if cols == nil {
+		cols = []*midjourney.Collection{}
+	}
+
+	return cols, nil
+}
+
+type wordResponse struct {
+	Word     string `json:"word"`
+	ImageID  string `json:"image_id"`
+	ImageURL string `json:"image_url"`
+}
+
+func (s *server) handleWords(r *http.Request) (any, error) {
+	params := r.URL.Query()
+	q := &midjourney.WordsQuery{Query: params.Get("query")}
+
+	var err error
+	ints := map[string]*int{
+		"amount": &q.Amount,
+		"page":   &q.Page,
+		"seed":   &q.Seed,
+	}
+	for k, p := range ints {
+		if v := params.Get(k); v != "" {
+			*p, err = strconv.Atoi(v)
+			if err != nil {
+				return nil, badRequest(k + " must be an integer")
+			}
+		}
+	}
+
+	words, err := s.backend.Words(r.Context(), q)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*wordResponse, 0, len(words))
+	for _, w := range words {
+		resp = append(resp, &wordResponse{
+			Word:     w.Word,
+			ImageID:  w.ImageID,
+			ImageURL: w.ImageURL(),
+		})
+	}
+	sort.Slice(resp, func(i, j int) bool { return resp[i].Word < resp[j].Word })
+
+	return resp, nil
+}
+
+func handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	writeJSONBytes(w, http.StatusOK, openAPISpec)
+}
+
+func badRequest(msg string) error {
+	return &requestError{msg: msg}
+}
+
+func errorStatus(err error) int {
+	var reqErr *requestError
+
+	switch {
+	case errors.As(err, &reqErr),
+		errors.Is(err, midjourney.ErrInvalidQuery):
+		return http.StatusBadRequest
+	case errors.Is(err, midjourney.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	b, _ := json.Marshal(&errorResponse{Error: err.Error()})
+	writeJSONBytes(w, status, b)
+}
+
+func writeJSONBytes(w http.ResponseWriter, status int, b []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
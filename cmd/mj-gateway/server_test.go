@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBackend struct {
+	calls int
+}
+
+func (b *fakeBackend) RecentJobs(
+	_ context.Context,
+	q *midjourney.RecentJobsQuery,
+) (*midjourney.RecentJobs, error) {
+	b.calls++
+
+	return &midjourney.RecentJobs{
+		Query: *q,
+		Page:  q.Page,
+		Jobs:  []*midjourney.Job{{ID: "job-1", UserID: q.UserID}},
+	}, nil
+}
+
+func (b *fakeBackend) GetJob(
+	_ context.Context,
+	id string,
+) (*midjourney.Job, error) {
+	b.calls++
+	if id != "job-1" {
+		return nil, fmt.Errorf("%w: id=%s", midjourney.ErrJobNotFound, id)
+	}
+
+	return &midjourney.Job{ID: id}, nil
+}
+
+func (b *fakeBackend) ArchiveDay(
+	_ context.Context,
+	date time.Time,
+) ([]string, error) {
+	b.calls++
+
+	return []string{date.Format("20060102")}, nil
+}
+
+func (b *fakeBackend) Collections(
+	_ context.Context,
+	q *midjourney.CollectionsQuery,
+) ([]*midjourney.Collection, error) {
+	b.calls++
+
+	return []*midjourney.Collection{{ID: "col", CreatorID: q.UserID}}, nil
+}
+
+func (b *fakeBackend) Words(
+	_ context.Context,
+	_ *midjourney.WordsQuery,
+) ([]*midjourney.Word, error) {
+	b.calls++
+
+	return []*midjourney.Word{
+		{Word: "zebra", ImageID: "z"},
+		{Word: "apple", ImageID: "a"},
+	}, nil
+}
+
+func newTestServer(b backend) http.Handler {
+	return newServer(&config{
+		Backend:     b,
+		APIKeys:     []string{"secret"},
+		CacheTTL:    time.Minute,
+		Rate:        100,
+		Burst:       100,
+		CORSOrigins: []string{"https://example.com"},
+		Logger:      zerolog.Nop(),
+	})
+}
+
+func doRequest(
+	h http.Handler,
+	method string,
+	target string,
+	headers map[string]string,
+) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+var authed = map[string]string{"X-API-Key": "secret"}
+
+func TestServer_Endpoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "jobs",
+			target:     "/jobs?userId=123&page=2",
+			wantStatus: http.StatusOK,
+			wantBody: `{"page":2,"jobs":[` +
+				`{"enqueue_time":null,"id":"job-1","user_id":"123"}]}`,
+		},
+		{
+			name:       "jobs invalid query",
+			target:     "/jobs?amount=lots",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "job",
+			target:     "/jobs/job-1",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"enqueue_time":null,"id":"job-1"}`,
+		},
+		{
+			name:       "job not found",
+			target:     "/jobs/nope",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "archive",
+			target:     "/archive/2022-12-11",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"date":"2022-12-11","job_ids":["20221211"]}`,
+		},
+		{
+			name:       "archive invalid date",
+			target:     "/archive/yesterday",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"date must be formatted as YYYY-MM-DD"}`,
+		},
+		{
+			name:       "collections",
+			target:     "/collections?user_id=123",
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"creator_id":"123","id":"col"}]`,
+		},
+		{
+			name:       "collections without params",
+			target:     "/collections",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "words",
+			target:     "/words?amount=2",
+			wantStatus: http.StatusOK,
+			wantBody: `[` +
+				`{"word":"apple","image_id":"a",` +
+				`"image_url":"https://i.mj.run/a/0_0.png"},` +
+				`{"word":"zebra","image_id":"z",` +
+				`"image_url":"https://i.mj.run/z/0_0.png"}]`,
+		},
+		{
+			name:       "unknown path",
+			target:     "/nope",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestServer(&fakeBackend{})
+
+			rec := doRequest(h, http.MethodGet, tt.target, authed)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_Auth(t *testing.T) {
+	h := newTestServer(&fakeBackend{})
+
+	rec := doRequest(h, http.MethodGet, "/jobs", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = doRequest(h, http.MethodGet, "/jobs",
+		map[string]string{"X-API-Key": "wrong"},
+	)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = doRequest(h, http.MethodGet, "/jobs",
+		map[string]string{"Authorization": "Bearer secret"},
+	)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = doRequest(h, http.MethodGet, "/openapi.json", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, json.Valid(rec.Body.Bytes()))
+}
+
+func TestServer_Cache(t *testing.T) {
+	b := &fakeBackend{}
+	h := newTestServer(b)
+
+	rec := doRequest(h, http.MethodGet, "/jobs?userId=1", authed)
+	assert.Equal(t, "MISS", rec.Header().Get("X-Cache"))
+
+	rec = doRequest(h, http.MethodGet, "/jobs?userId=1", authed)
+	assert.Equal(t, "HIT", rec.Header().Get("X-Cache"))
+
+	rec = doRequest(h, http.MethodGet, "/jobs?userId=2", authed)
+	assert.Equal(t, "MISS", rec.Header().Get("X-Cache"))
+
+	assert.Equal(t, 2, b.calls)
+}
+
+func TestServer_MethodNotAllowed(t *testing.T) {
+	h := newTestServer(&fakeBackend{})
+
+	rec := doRequest(h, http.MethodPost, "/jobs", authed)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	h := newServer(&config{
+		Backend: &fakeBackend{},
+		APIKeys: []string{"a", "b"},
+		Rate:    0.001,
+		Burst:   2,
+		Logger:  zerolog.Nop(),
+	})
+	keyA := map[string]string{"X-API-Key": "a"}
+
+	for i := 0; i < 2; i++ {
+		rec := doRequest(h, http.MethodGet, "/jobs", keyA)
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	rec := doRequest(h, http.MethodGet, "/jobs", keyA)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	rec = doRequest(h, http.MethodGet, "/jobs",
+		map[string]string{"X-API-Key": "b"},
+	)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServer_CORS(t *testing.T) {
+	h := newTestServer(&fakeBackend{})
+
+	rec := doRequest(h, http.MethodOptions, "/jobs", map[string]string{
+		"Origin":                        "https://example.com",
+		"Access-Control-Request-Method": "GET",
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t,
+		"https://example.com",
+		rec.Header().Get("Access-Control-Allow-Origin"),
+	)
+	assert.Contains(t,
+		rec.Header().Get("Access-Control-Allow-Headers"), "X-API-Key",
+	)
+
+	rec = doRequest(h, http.MethodGet, "/jobs", map[string]string{
+		"Origin":    "https://other.com",
+		"X-API-Key": "secret",
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestLimiter_Refill(t *testing.T) {
+	now := time.Date(2022, 12, 11, 0, 0, 0, 0, time.UTC)
+	l := newLimiter(1, 1)
+	l.now = func() time.Time { return now }
+
+	ok, _ := l.Allow("k")
+	assert.True(t, ok)
+
+	ok, wait := l.Allow("k")
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+
+	now = now.Add(time.Second)
+	ok, _ = l.Allow("k")
+	assert.True(t, ok)
+}
+
+func TestCache_Expiry(t *testing.T) {
+	now := time.Date(2022, 12, 11, 0, 0, 0, 0, time.UTC)
+	c := newCache(time.Minute, 2)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", []byte("1"))
+	now = now.Add(30 * time.Second)
+	c.Set("b", []byte("2"))
+
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), v)
+
+	c.Set("c", []byte("3"))
+	_, ok = c.Get("a")
+	assert.False(t, ok, "oldest entry should be evicted")
+
+	now = now.Add(time.Minute)
+	_, ok = c.Get("b")
+	assert.False(t, ok, "entry should have expired")
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/jimeh/go-midjourney/drift"
+)
+
+var driftCommand = &command{
+	Name:  "drift",
+	Usage: "compare API response shapes against expected types",
+	Run:   runDrift,
+}
+
+var errDrift = errors.New("API schema drift detected")
+
+func runDrift(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("drift", flag.ContinueOnError)
+	fixtures := fs.String(
+		"fixtures", "", "directory of recorded responses to check offline",
+	)
+	record := fs.String(
+		"record", "", "directory to record fetched responses to",
+	)
+	userID := fs.String("user", "", "user ID to fetch collections of")
+	format := fs.String("format", "text", "output format: text or json")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+	if *fixtures != "" && *record != "" {
+		return fmt.Errorf(
+			"%w: -fixtures and -record are mutually exclusive", errUsage,
+		)
+	}
+
+	var src drift.Source
+	if *fixtures != "" {
+		src = drift.DirSource(*fixtures)
+	} else {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		src = &drift.APISource{API: client.API}
+
+		if *record != "" {
+			src = &drift.Recorder{Source: src, Dir: *record}
+		}
+	}
+
+	reports, err := drift.Check(
+		ctx, src, drift.Endpoints(), &drift.Env{UserID: *userID},
+	)
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(reports)
+	} else {
+		err = drift.WriteText(stdout, reports)
+	}
+	if err != nil {
+		return err
+	}
+
+	if drift.Drifted(reports) {
+		return errDrift
+	}
+
+	return nil
+}
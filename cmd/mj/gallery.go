@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/jimeh/go-midjourney/gallery"
+)
+
+var galleryCommand = &command{
+	Name:  "gallery",
+	Usage: "generate a static HTML gallery of jobs",
+	Run:   runGallery,
+}
+
+func runGallery(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("gallery", flag.ContinueOnError)
+	out := fs.String("out", "gallery", "output directory")
+	collectionID := fs.String("collection", "", "collection ID")
+	userID := fs.String("user", "", "user ID to render the jobs of")
+	jobsFile := fs.String("jobs", "", "JSON file with an array of jobs")
+	pages := fs.Int("pages", 1, "number of API result pages to fetch")
+	perPage := fs.Int("per-page", gallery.DefaultPerPage, "jobs per page")
+	title := fs.String("title", "", "gallery title")
+	imageDir := fs.String("images", "", "directory with local images")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	site := &gallery.Site{}
+
+	switch {
+	case *jobsFile != "":
+		site.Jobs, err = readJobsFile(*jobsFile)
+		if err != nil {
+			return err
+		}
+	case *collectionID != "" || *userID != "":
+		site, err = fetchGallerySite(ctx, *collectionID, *userID, *pages)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf(
+			"%w: one of -jobs, -collection or -user is required", errUsage,
+		)
+	}
+
+	if *title != "" {
+		site.Title = *title
+	} else if site.Title == "" {
+		site.Title = "MidJourney Gallery"
+	}
+	site.PerPage = *perPage
+	site.ImageDir = *imageDir
+
+	err = site.Generate(ctx, *out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "wrote %d jobs to %s\n", len(site.Jobs), *out)
+
+	return nil
+}
+
+func fetchGallerySite(
+	ctx context.Context,
+	collectionID string,
+	userID string,
+	pages int,
+) (*gallery.Site, error) {
+	c, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	q := &midjourney.RecentJobsQuery{
+		Amount:    50,
+		JobType:   midjourney.JobTypeNull,
+		OrderBy:   midjourney.OrderNew,
+		JobStatus: midjourney.JobStatusCompleted,
+		Dedupe:    true,
+	}
+
+	if collectionID == "" {
+		q.UserID = userID
+		jobs, err := fetchJobs(ctx, c, q, pages)
+		if err != nil {
+			return nil, err
+		}
+
+		return &gallery.Site{Jobs: jobs}, nil
+	}
+
+	col, err := c.GetCollection(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	q.CollectionID = collectionID
+	jobs, err := fetchJobs(ctx, c, q, pages)
+	if err != nil {
+		return nil, err
+	}
+
+	return gallery.CollectionSite(col, jobs), nil
+}
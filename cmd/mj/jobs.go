@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/jimeh/go-midjourney/internal/jobfile"
+)
+
+// fetchJobs fetches up to the given number of pages of jobs matching q.
+func fetchJobs(
+	ctx context.Context,
+	c *midjourney.Client,
+	q *midjourney.RecentJobsQuery,
+	pages int,
+) ([]*midjourney.Job, error) {
+	jobs := []*midjourney.Job{}
+	for i := 0; i < pages; i++ {
+		rj, err := c.RecentJobs(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		if len(rj.Jobs) == 0 {
+			break
+		}
+
+		jobs = append(jobs, rj.Jobs...)
+		q = rj.Query.NextPage()
+	}
+
+	return jobs, nil
+}
+
+// readJobsFile reads all jobs from the given file.
+func readJobsFile(path string) ([]*midjourney.Job, error) {
+	jobs := []*midjourney.Job{}
+	err := jobfile.ReadFile(path, func(j *midjourney.Job) error {
+		jobs = append(jobs, j)
+
+		return nil
+	})
+
+	return jobs, err
+}
@@ -0,0 +1,95 @@
+// Command mj is a command line tool for working with MidJourney jobs and
+// collections.
+//
+// The auth token is read from the MIDJOURNEY_AUTH_TOKEN environment variable,
+// and an alternative API URL can be set with MIDJOURNEY_API_URL.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+type command struct {
+	Name  string
+	Usage string
+	Run   func(ctx context.Context, args []string, stdout io.Writer) error
+}
+
+var commands = []*command{
+	driftCommand,
+	galleryCommand,
+	statsCommand,
+}
+
+var errUsage = errors.New("usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	case errors.Is(err, errUsage):
+		if err != errUsage { //nolint:errorlint
+			fmt.Fprintf(os.Stderr, "mj: %s\n", err)
+		}
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "mj: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(
+	ctx context.Context,
+	args []string,
+	stdout io.Writer,
+	stderr io.Writer,
+) error {
+	if len(args) == 0 {
+		usage(stderr)
+
+		return errUsage
+	}
+
+	for _, c := range commands {
+		if c.Name == args[0] {
+			return c.Run(ctx, args[1:], stdout)
+		}
+	}
+
+	fmt.Fprintf(stderr, "mj: unknown command %q\n\n", args[0])
+	usage(stderr)
+
+	return errUsage
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: mj <command> [flags]\n\nCommands:\n")
+
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.Name, c.Usage)
+	}
+}
+
+func newClient() (*midjourney.Client, error) {
+	opts := []midjourney.Option{
+		midjourney.WithAuthToken(os.Getenv("MIDJOURNEY_AUTH_TOKEN")),
+	}
+	if u := os.Getenv("MIDJOURNEY_API_URL"); u != "" {
+		opts = append(opts, midjourney.WithAPIURL(u))
+	}
+
+	return midjourney.New(opts...)
+}
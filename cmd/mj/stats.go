@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/jimeh/go-midjourney/internal/jobfile"
+	"github.com/jimeh/go-midjourney/stats"
+)
+
+var statsCommand = &command{
+	Name:  "stats",
+	Usage: "compute usage statistics over a job history",
+	Run:   runStats,
+}
+
+func runStats(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	userID := fs.String("user", "", "user ID to fetch jobs of from the API")
+	pages := fs.Int("pages", 10, "number of API result pages to fetch")
+	mirror := fs.String("mirror", "", "directory with mirrored job JSON files")
+	format := fs.String("format", "json", "output format: json or csv")
+	top := fs.Int("top", stats.DefaultTopTerms, "number of top prompt terms")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+
+	c := stats.NewCollector()
+	c.TopTerms = *top
+
+	switch {
+	case *mirror != "":
+		err = jobfile.ReadDir(*mirror, func(j *midjourney.Job) error {
+			c.Add(j)
+
+			return nil
+		})
+	case *userID != "":
+		var client *midjourney.Client
+		client, err = newClient()
+		if err != nil {
+			return err
+		}
+
+		var jobs []*midjourney.Job
+		jobs, err = fetchJobs(ctx, client, &midjourney.RecentJobsQuery{
+			Amount:    50,
+			JobType:   midjourney.JobTypeNull,
+			OrderBy:   midjourney.OrderNew,
+			JobStatus: midjourney.JobStatusCompleted,
+			UserID:    *userID,
+		}, *pages)
+		c.Add(jobs...)
+	default:
+		return fmt.Errorf("%w: one of -mirror or -user is required", errUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *format == "csv" {
+		return c.Stats().WriteCSV(stdout)
+	}
+
+	return c.Stats().WriteJSON(stdout)
+}
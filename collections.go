@@ -2,6 +2,7 @@ package midjourney
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 )
@@ -13,7 +14,7 @@ var (
 
 type Collection struct {
 	CoverJobID         string          `json:"cover_job_id,omitempty"`
-	Created            string          `json:"created,omitempty"`
+	Created            *Time           `json:"created,omitempty"`
 	CreatorAvatarJobID string          `json:"creator_avatar_job_id,omitempty"`
 	CreatorCoverJobID  string          `json:"creator_cover_job_id,omitempty"`
 	CreatorID          string          `json:"creator_id,omitempty"`
@@ -22,17 +23,44 @@ type Collection struct {
 	Description        string          `json:"description,omitempty"`
 	Hidden             bool            `json:"hidden,omitempty"`
 	ID                 string          `json:"id,omitempty"`
-	NumJobs            int             `json:"num_jobs,omitempty"`
+	NumJobs            *FlexInt        `json:"num_jobs,omitempty"`
 	Public             bool            `json:"public,omitempty"`
 	PublicEditable     bool            `json:"public_editable,omitempty"`
 	SearchTerms        []string        `json:"search_terms,omitempty"`
 	Title              string          `json:"title,omitempty"`
 	Workspaces         []string        `json:"workspaces,omitempty"`
+
+	// Extra holds any JSON fields not modeled above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (c *Collection) UnmarshalJSON(b []byte) error {
+	type collection Collection
+	extra, err := unmarshalWithExtra(b, (*collection)(c))
+	if err != nil {
+		return err
+	}
+	c.Extra = extra
+
+	return nil
+}
+
+func (c Collection) MarshalJSON() ([]byte, error) {
+	type collection Collection
+
+	return marshalWithExtra((*collection)(&c), c.Extra)
+}
+
+func (c *Collection) extraFields() map[string]json.RawMessage {
+	return c.Extra
 }
 
 type CollectionsQuery struct {
 	UserID       string `url:"user_id,omitempty"`
 	CollectionID string `url:"collection_id,omitempty"`
+
+	// IncludeHidden includes hidden (deleted) collections in the result.
+	IncludeHidden bool `url:"include_hidden,omitempty"`
 }
 
 func (cq *CollectionsQuery) URLValues() url.Values {
@@ -44,6 +72,9 @@ func (cq *CollectionsQuery) URLValues() url.Values {
 	if cq.CollectionID != "" {
 		v.Set("collection_id", cq.CollectionID)
 	}
+	if cq.IncludeHidden {
+		v.Set("include_hidden", "true")
+	}
 
 	return v
 }
@@ -86,7 +117,7 @@ func (c *Client) PutCollection(
 	ctx context.Context,
 	collection *Collection,
 ) (*Collection, error) {
-	var col *Collection
+	col := &Collection{}
 
 	err := c.API.Put(ctx, "app/collections/", nil, collection, col)
 
@@ -101,13 +132,70 @@ func (c *Client) DeleteCollection(
 		return nil, ErrCollectionIDRequired
 	}
 
-	var col *Collection
+	return c.putCollectionHidden(ctx, collectionID, true)
+}
+
+// HiddenCollections returns all hidden (deleted) collections for the given
+// user.
+func (c *Client) HiddenCollections(
+	ctx context.Context,
+	userID string,
+) ([]*Collection, error) {
+	if userID == "" {
+		return nil, ErrUserIDRequired
+	}
+
+	cols, err := c.Collections(ctx, &CollectionsQuery{
+		UserID:        userID,
+		IncludeHidden: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hidden := make([]*Collection, 0, len(cols))
+	for _, col := range cols {
+		if col.Hidden {
+			hidden = append(hidden, col)
+		}
+	}
+
+	return hidden, nil
+}
+
+// RestoreCollection restores a hidden (deleted) collection by clearing its
+// hidden flag.
+func (c *Client) RestoreCollection(
+	ctx context.Context,
+	collectionID string,
+) (*Collection, error) {
+	if collectionID == "" {
+		return nil, ErrCollectionIDRequired
+	}
+
+	return c.putCollectionHidden(ctx, collectionID, false)
+}
+
+// collectionHiddenRequest is used instead of Collection when toggling the
+// hidden flag, as the omitempty tag on Collection.Hidden prevents a false
+// value from ever being sent.
+type collectionHiddenRequest struct {
+	ID     string `json:"id"`
+	Hidden bool   `json:"hidden"`
+}
+
+func (c *Client) putCollectionHidden(
+	ctx context.Context,
+	collectionID string,
+	hidden bool,
+) (*Collection, error) {
+	col := &Collection{}
 
 	// Deletion of a collection is strangely done by setting the hidden flag to
 	// true. This is a bit confusing, but it's how the API works.
 	err := c.API.Put(
 		ctx, "app/collections/", nil,
-		&Collection{ID: collectionID, Hidden: true}, col,
+		&collectionHiddenRequest{ID: collectionID, Hidden: hidden}, col,
 	)
 
 	return col, err
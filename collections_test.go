@@ -0,0 +1,196 @@
+package midjourney
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCollectionsServer is a minimal in-memory implementation of the
+// app/collections/ endpoint which preserves the hidden state of collections.
+type fakeCollectionsServer struct {
+	*fakeServer
+	collections map[string]*Collection
+}
+
+func newFakeCollectionsServer(cols ...*Collection) *fakeCollectionsServer {
+	s := &fakeCollectionsServer{
+		fakeServer:  newFakeServer(),
+		collections: map[string]*Collection{},
+	}
+	for _, col := range cols {
+		s.collections[col.ID] = col
+	}
+	s.handle("/app/collections/", s.serveCollections)
+
+	return s
+}
+
+func (s *fakeCollectionsServer) serveCollections(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		includeHidden := q.Get("include_hidden") == "true"
+
+		cols := []*Collection{}
+		for _, col := range s.collections {
+			if q.Get("user_id") != "" && col.CreatorID != q.Get("user_id") {
+				continue
+			}
+			if q.Get("collection_id") != "" &&
+				col.ID != q.Get("collection_id") {
+				continue
+			}
+			if col.Hidden && !includeHidden {
+				continue
+			}
+			cols = append(cols, col)
+		}
+		sort.Slice(cols, func(i, j int) bool {
+			return cols[i].ID < cols[j].ID
+		})
+
+		_ = json.NewEncoder(w).Encode(cols)
+	case http.MethodPut:
+		var req map[string]json.RawMessage
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		var id string
+		_ = json.Unmarshal(req["id"], &id)
+
+		col, ok := s.collections[id]
+		if !ok {
+			_ = json.NewEncoder(w).Encode(
+				&ResponseError{Message: "not found"},
+			)
+
+			return
+		}
+		if raw, ok := req["hidden"]; ok {
+			_ = json.Unmarshal(raw, &col.Hidden)
+		}
+
+		_ = json.NewEncoder(w).Encode(col)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestCollectionsQuery_URLValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *CollectionsQuery
+		want  string
+	}{
+		{
+			name:  "empty",
+			query: &CollectionsQuery{},
+			want:  "",
+		},
+		{
+			name:  "user id",
+			query: &CollectionsQuery{UserID: "123"},
+			want:  "user_id=123",
+		},
+		{
+			name: "include hidden",
+			query: &CollectionsQuery{
+				UserID:        "123",
+				IncludeHidden: true,
+			},
+			want: "include_hidden=true&user_id=123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.query.URLValues()
+
+			assert.Equal(t, tt.want, got.Encode())
+		})
+	}
+}
+
+func TestClient_HiddenCollections(t *testing.T) {
+	srv := newFakeCollectionsServer(
+		&Collection{ID: "a", CreatorID: "u1"},
+		&Collection{ID: "b", CreatorID: "u1", Hidden: true},
+		&Collection{ID: "c", CreatorID: "u1", Hidden: true},
+		&Collection{ID: "d", CreatorID: "u2", Hidden: true},
+	)
+	c := newTestClient(t, srv)
+
+	got, err := c.HiddenCollections(context.Background(), "u1")
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(got))
+	for _, col := range got {
+		ids = append(ids, col.ID)
+	}
+	assert.Equal(t, []string{"b", "c"}, ids)
+}
+
+func TestClient_HiddenCollections_UserIDRequired(t *testing.T) {
+	c, err := New()
+	require.NoError(t, err)
+
+	_, err = c.HiddenCollections(context.Background(), "")
+
+	assert.ErrorIs(t, err, ErrUserIDRequired)
+}
+
+func TestClient_DeleteAndRestoreCollection(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeCollectionsServer(
+		&Collection{ID: "a", CreatorID: "u1", Title: "Foo"},
+	)
+	c := newTestClient(t, srv)
+
+	col, err := c.DeleteCollection(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, col.Hidden)
+
+	cols, err := c.Collections(ctx, &CollectionsQuery{UserID: "u1"})
+	require.NoError(t, err)
+	assert.Empty(t, cols)
+
+	hidden, err := c.HiddenCollections(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, hidden, 1)
+	assert.Equal(t, "a", hidden[0].ID)
+
+	col, err = c.RestoreCollection(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, col.Hidden)
+	assert.Equal(t, "Foo", col.Title)
+
+	cols, err = c.Collections(ctx, &CollectionsQuery{UserID: "u1"})
+	require.NoError(t, err)
+	require.Len(t, cols, 1)
+	assert.Equal(t, "a", cols[0].ID)
+
+	hidden, err = c.HiddenCollections(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, hidden)
+}
+
+func TestClient_RestoreCollection_CollectionIDRequired(t *testing.T) {
+	c, err := New()
+	require.NoError(t, err)
+
+	_, err = c.RestoreCollection(context.Background(), "")
+
+	assert.ErrorIs(t, err, ErrCollectionIDRequired)
+}
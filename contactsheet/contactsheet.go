@@ -0,0 +1,492 @@
+// Package contactsheet renders many jobs into a single image of captioned
+// thumbnails.
+package contactsheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+const (
+	DefaultColumns     = 4
+	DefaultTileWidth   = 256
+	DefaultTileHeight  = 256
+	DefaultPadding     = 8
+	DefaultFontScale   = 2
+	DefaultPromptLines = 2
+	DefaultJPEGQuality = 90
+)
+
+var (
+	Err                  = errors.New("contactsheet")
+	ErrNoJobs            = fmt.Errorf("%w: no jobs", Err)
+	ErrImageNotFound     = fmt.Errorf("%w: image not found", Err)
+	ErrFetch             = fmt.Errorf("%w: fetch failed", Err)
+	ErrUnsupportedFormat = fmt.Errorf("%w: unsupported format", Err)
+)
+
+type Format string
+
+const (
+	FormatPNG  Format = "png"
+	FormatJPEG Format = "jpeg"
+)
+
+// FormatFromFilename returns the format matching the extension of the given
+// filename, defaulting to PNG.
+func FormatFromFilename(name string) Format {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".jpg", ".jpeg":
+		return FormatJPEG
+	}
+
+	return FormatPNG
+}
+
+// Source loads the image to show for a job.
+type Source func(ctx context.Context, job *midjourney.Job) (image.Image, error)
+
+// ThumbnailSource returns a Source which fetches the job's grid thumbnail of
+// the given size. As the standard library cannot decode WebP images, the PNG
+// variant of the thumbnail is fetched.
+func ThumbnailSource(
+	client midjourney.HTTPClient,
+	size midjourney.ThumbnailSize,
+) Source {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(ctx context.Context, job *midjourney.Job) (image.Image, error) {
+		u := midjourney.DefaultAssets.GridThumbnailURL(
+			job, size, midjourney.ImageFormatPNG,
+		)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%w: %s: %s", ErrFetch, u, resp.Status)
+		}
+
+		img, _, err := image.Decode(resp.Body)
+
+		return img, err
+	}
+}
+
+// DirSource returns a Source which loads PNG or JPEG images from dir, named
+// after the job's ImageFilename, or its ID.
+func DirSource(dir string) Source {
+	return func(_ context.Context, job *midjourney.Job) (image.Image, error) {
+		names := []string{
+			job.ImageFilename(),
+			job.ID + ".png",
+			job.ID + ".jpg",
+			job.ID + ".jpeg",
+		}
+		for _, name := range names {
+			f, err := os.Open(filepath.Join(dir, name))
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			img, _, err := image.Decode(f)
+			_ = f.Close()
+
+			return img, err
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrImageNotFound, job.ID)
+	}
+}
+
+// FallbackSource returns a Source which tries each of the given sources in
+// order, returning the first image loaded.
+func FallbackSource(sources ...Source) Source {
+	return func(ctx context.Context, job *midjourney.Job) (image.Image, error) {
+		err := fmt.Errorf("%w: %s", ErrImageNotFound, job.ID)
+		for _, src := range sources {
+			var img image.Image
+			img, err = src(ctx, job)
+			if err == nil {
+				return img, nil
+			}
+		}
+
+		return nil, err
+	}
+}
+
+// Options configures the layout of a contact sheet. The zero value uses the
+// package defaults.
+type Options struct {
+	// Columns is the number of tiles per row.
+	Columns int
+
+	// TileWidth and TileHeight is the box each image is scaled to fit within.
+	TileWidth  int
+	TileHeight int
+
+	// Padding is the space around each tile. Negative values disable it.
+	Padding int
+
+	// FontScale is the size of caption text, as a multiple of the 5x7 pixel
+	// font.
+	FontScale int
+
+	// PromptLines is the number of caption lines used for the prompt, which
+	// is truncated to fit. The job ID is always shown on an additional line.
+	PromptLines int
+
+	Background color.Color
+	Foreground color.Color
+
+	// Source loads the image of each job. Defaults to the medium size
+	// thumbnail of each job's grid.
+	Source Source
+
+	// Concurrency is the maximum number of images loaded at once. Defaults to
+	// midjourney.DefaultConcurrency.
+	Concurrency int
+
+	// JPEGQuality is used when encoding JPEG images.
+	JPEGQuality int
+}
+
+func (o *Options) withDefaults() *Options {
+	opts := &Options{}
+	if o != nil {
+		*opts = *o
+	}
+
+	if opts.Columns <= 0 {
+		opts.Columns = DefaultColumns
+	}
+	if opts.TileWidth <= 0 {
+		opts.TileWidth = DefaultTileWidth
+	}
+	if opts.TileHeight <= 0 {
+		opts.TileHeight = DefaultTileHeight
+	}
+	if opts.Padding < 0 {
+		opts.Padding = 0
+	} else if opts.Padding == 0 {
+		opts.Padding = DefaultPadding
+	}
+	if opts.FontScale <= 0 {
+		opts.FontScale = DefaultFontScale
+	}
+	if opts.PromptLines <= 0 {
+		opts.PromptLines = DefaultPromptLines
+	}
+	if opts.Background == nil {
+		opts.Background = color.White
+	}
+	if opts.Foreground == nil {
+		opts.Foreground = color.Black
+	}
+	if opts.Source == nil {
+		opts.Source = ThumbnailSource(nil, midjourney.ThumbnailSizeMedium)
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = midjourney.DefaultConcurrency
+	}
+	if opts.JPEGQuality <= 0 {
+		opts.JPEGQuality = DefaultJPEGQuality
+	}
+
+	return opts
+}
+
+// captionHeight returns the height of the caption below each tile.
+func (o *Options) captionHeight() int {
+	return (o.PromptLines + 1) * lineHeight * o.FontScale
+}
+
+// lineChars returns the number of caption characters which fit on one line.
+func (o *Options) lineChars() int {
+	return o.TileWidth / (advance * o.FontScale)
+}
+
+// Render draws the images of the given jobs into a grid, with each image
+// captioned with its job's prompt and ID. Nil jobs are skipped.
+//
+// Jobs whose image cannot be loaded are drawn with an empty tile. The sheet is
+// still returned in that case, along with a *midjourney.BulkError keyed by job
+// ID.
+func Render(
+	ctx context.Context,
+	jobs []*midjourney.Job,
+	opts *Options,
+) (*image.RGBA, error) {
+	return render(ctx, jobs, opts.withDefaults())
+}
+
+func render(
+	ctx context.Context,
+	jobs []*midjourney.Job,
+	opts *Options,
+) (*image.RGBA, error) {
+	jobs = skipNil(jobs)
+	if len(jobs) == 0 {
+		return nil, ErrNoJobs
+	}
+
+	images, errs := load(ctx, jobs, opts)
+
+	cols := opts.Columns
+	if len(jobs) < cols {
+		cols = len(jobs)
+	}
+	rows := (len(jobs) + cols - 1) / cols
+	cellW := opts.TileWidth + opts.Padding
+	cellH := opts.TileHeight + opts.captionHeight() + opts.Padding
+
+	sheet := image.NewRGBA(image.Rect(
+		0, 0, cols*cellW+opts.Padding, rows*cellH+opts.Padding,
+	))
+	draw.Draw(
+		sheet, sheet.Bounds(), image.NewUniform(opts.Background),
+		image.Point{}, draw.Src,
+	)
+
+	for i, job := range jobs {
+		x := opts.Padding + (i%cols)*cellW
+		y := opts.Padding + (i/cols)*cellH
+		tile := image.Rect(x, y, x+opts.TileWidth, y+opts.TileHeight)
+
+		if images[i] != nil {
+			drawFit(sheet, tile, images[i])
+		} else {
+			fillRect(sheet, tile, color.Gray{Y: 0xcc})
+		}
+
+		lines := caption(job, opts.PromptLines, opts.lineChars())
+		for n, line := range lines {
+			drawText(sheet, image.Pt(
+				x, tile.Max.Y+(n*lineHeight+2)*opts.FontScale,
+			), line, opts.Foreground, opts.FontScale)
+		}
+	}
+
+	if len(errs) == 0 {
+		return sheet, nil
+	}
+
+	succeeded := []string{}
+	for _, job := range jobs {
+		if _, ok := errs[job.ID]; !ok {
+			succeeded = append(succeeded, job.ID)
+		}
+	}
+	sort.Strings(succeeded)
+
+	return sheet, &midjourney.BulkError{Errors: errs, Succeeded: succeeded}
+}
+
+// Write renders a contact sheet of the given jobs, and writes it to w in the
+// given format. As with Render, a *midjourney.BulkError is returned after the
+// sheet has been written if any images could not be loaded.
+func Write(
+	ctx context.Context,
+	w io.Writer,
+	jobs []*midjourney.Job,
+	format Format,
+	opts *Options,
+) error {
+	if format != FormatPNG && format != FormatJPEG {
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+	opts = opts.withDefaults()
+
+	sheet, renderErr := render(ctx, jobs, opts)
+	if sheet == nil {
+		return renderErr
+	}
+
+	var err error
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(w, sheet, &jpeg.Options{Quality: opts.JPEGQuality})
+	case FormatPNG:
+		err = png.Encode(w, sheet)
+	}
+	if err != nil {
+		return err
+	}
+
+	return renderErr
+}
+
+// load loads the images of all jobs with the configured source, returning
+// errors keyed by job ID.
+// skipNil returns jobs without any nil entries.
+func skipNil(jobs []*midjourney.Job) []*midjourney.Job {
+	out := make([]*midjourney.Job, 0, len(jobs))
+	for _, j := range jobs {
+		if j != nil {
+			out = append(out, j)
+		}
+	}
+
+	return out
+}
+
+func load(
+	ctx context.Context,
+	jobs []*midjourney.Job,
+	opts *Options,
+) ([]image.Image, map[string]error) {
+	var (
+		wg     sync.WaitGroup
+		mux    sync.Mutex
+		images = make([]image.Image, len(jobs))
+		errs   = map[string]error{}
+		sem    = make(chan struct{}, opts.Concurrency)
+	)
+
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, job *midjourney.Job) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			img, err := opts.Source(ctx, job)
+			if err == nil && img == nil {
+				err = fmt.Errorf("%w: %s", ErrImageNotFound, job.ID)
+			}
+
+			mux.Lock()
+			defer mux.Unlock()
+			if err != nil {
+				errs[job.ID] = err
+
+				return
+			}
+			images[i] = img
+		}(i, job)
+	}
+	wg.Wait()
+
+	return images, errs
+}
+
+// caption returns the caption lines for a job: its prompt split into at most
+// promptLines lines of width characters, truncated with an ellipsis if needed,
+// followed by its ID.
+func caption(job *midjourney.Job, promptLines int, width int) []string {
+	if width < 4 {
+		return nil
+	}
+
+	prompt := []rune(strings.Join(strings.Fields(job.Prompt), " "))
+	if limit := promptLines * width; len(prompt) > limit {
+		prompt = append(prompt[:limit-3], []rune("...")...)
+	}
+
+	lines := []string{}
+	for len(prompt) > 0 {
+		if prompt[0] == ' ' {
+			prompt = prompt[1:]
+
+			continue
+		}
+
+		n := width
+		if n > len(prompt) {
+			n = len(prompt)
+		}
+		lines = append(lines, string(prompt[:n]))
+		prompt = prompt[n:]
+	}
+
+	id := []rune(job.ID)
+	if len(id) > width {
+		id = id[:width]
+	}
+
+	return append(lines, string(id))
+}
+
+// drawFit draws src scaled to fit within r, centered, preserving its aspect
+// ratio. Each destination pixel is the average of the source pixels it covers.
+func drawFit(dst *image.RGBA, r image.Rectangle, src image.Image) {
+	sb := src.Bounds()
+	if sb.Empty() || r.Empty() {
+		return
+	}
+
+	w, h := r.Dx(), sb.Dy()*r.Dx()/sb.Dx()
+	if h > r.Dy() {
+		w, h = sb.Dx()*r.Dy()/sb.Dy(), r.Dy()
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	ox := r.Min.X + (r.Dx()-w)/2
+	oy := r.Min.Y + (r.Dy()-h)/2
+
+	for y := 0; y < h; y++ {
+		sy0 := sb.Min.Y + y*sb.Dy()/h
+		sy1 := sb.Min.Y + (y+1)*sb.Dy()/h
+		if sy1 <= sy0 {
+			sy1 = sy0 + 1
+		}
+		for x := 0; x < w; x++ {
+			sx0 := sb.Min.X + x*sb.Dx()/w
+			sx1 := sb.Min.X + (x+1)*sb.Dx()/w
+			if sx1 <= sx0 {
+				sx1 = sx0 + 1
+			}
+			dst.Set(ox+x, oy+y, average(src, sx0, sy0, sx1, sy1))
+		}
+	}
+}
+
+func average(src image.Image, x0, y0, x1, y1 int) color.RGBA64 {
+	var r, g, b, a, n uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			cr, cg, cb, ca := src.At(x, y).RGBA()
+			r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+			n++
+		}
+	}
+
+	return color.RGBA64{
+		R: uint16(r / n),
+		G: uint16(g / n),
+		B: uint16(b / n),
+		A: uint16(a / n),
+	}
+}
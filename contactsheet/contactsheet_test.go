@@ -0,0 +1,292 @@
+package contactsheet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+	grey = color.RGBA{R: 0xcc, G: 0xcc, B: 0xcc, A: 255}
+)
+
+func solidImage(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+
+	return img
+}
+
+// mapSource returns images by job ID, failing for unknown jobs.
+func mapSource(images map[string]image.Image) Source {
+	return func(_ context.Context, job *midjourney.Job) (image.Image, error) {
+		img, ok := images[job.ID]
+		if !ok {
+			return nil, ErrImageNotFound
+		}
+
+		return img, nil
+	}
+}
+
+func testOptions(src Source) *Options {
+	return &Options{
+		Columns:     2,
+		TileWidth:   40,
+		TileHeight:  30,
+		Padding:     4,
+		FontScale:   1,
+		PromptLines: 1,
+		Source:      src,
+	}
+}
+
+func rgba(c color.Color) color.RGBA {
+	return color.RGBAModel.Convert(c).(color.RGBA)
+}
+
+func TestRender(t *testing.T) {
+	jobs := []*midjourney.Job{
+		{ID: "a", Prompt: "a wide red image"},
+		{ID: "b", Prompt: "a tall blue image"},
+		{ID: "c", Prompt: "missing"},
+		{ID: "d"},
+		{ID: "e"},
+	}
+	src := mapSource(map[string]image.Image{
+		"a": solidImage(40, 20, red),
+		"b": solidImage(10, 30, blue),
+		"d": solidImage(4, 3, red),
+		"e": solidImage(400, 300, blue),
+	})
+
+	sheet, err := Render(context.Background(), jobs, testOptions(src))
+
+	var be *midjourney.BulkError
+	require.True(t, errors.As(err, &be))
+	assert.Equal(t, []string{"c"}, be.IDs())
+	assert.Equal(t, []string{"a", "b", "d", "e"}, be.Succeeded)
+	assert.ErrorIs(t, err, ErrImageNotFound)
+
+	// 2 columns of 40px tiles, and 3 rows of 30px tiles with 20px captions,
+	// with 4px padding.
+	require.Equal(t, image.Rect(0, 0, 92, 166), sheet.Bounds())
+
+	// Wide image is letterboxed, tall image is pillarboxed.
+	assert.Equal(t, red, rgba(sheet.At(24, 19)))
+	assert.Equal(t, rgba(color.White), rgba(sheet.At(24, 6)))
+	assert.Equal(t, blue, rgba(sheet.At(68, 19)))
+	assert.Equal(t, rgba(color.White), rgba(sheet.At(50, 19)))
+
+	// Missing image is drawn as an empty tile.
+	assert.Equal(t, grey, rgba(sheet.At(24, 73)))
+
+	// Small and large images are scaled to fit.
+	assert.Equal(t, red, rgba(sheet.At(48, 59)))
+	assert.Equal(t, red, rgba(sheet.At(87, 87)))
+	assert.Equal(t, blue, rgba(sheet.At(5, 113)))
+	assert.Equal(t, blue, rgba(sheet.At(43, 141)))
+
+	// Captions are drawn below each tile.
+	dark := 0
+	for y := 34; y < 54; y++ {
+		for x := 4; x < 44; x++ {
+			if rgba(sheet.At(x, y)) == rgba(color.Black) {
+				dark++
+			}
+		}
+	}
+	assert.Greater(t, dark, 10)
+}
+
+func TestRender_NoJobs(t *testing.T) {
+	_, err := Render(context.Background(), nil, nil)
+
+	assert.ErrorIs(t, err, ErrNoJobs)
+}
+
+func TestRender_NilJobs(t *testing.T) {
+	src := mapSource(map[string]image.Image{"a": solidImage(4, 3, red)})
+
+	sheet, err := Render(context.Background(),
+		[]*midjourney.Job{nil, {ID: "a"}, nil}, testOptions(src),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 48, 58), sheet.Bounds())
+
+	_, err = Render(context.Background(), []*midjourney.Job{nil}, nil)
+	assert.ErrorIs(t, err, ErrNoJobs)
+}
+
+func TestWrite(t *testing.T) {
+	jobs := []*midjourney.Job{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	src := mapSource(map[string]image.Image{
+		"a": solidImage(8, 8, red),
+		"b": solidImage(8, 8, blue),
+		"c": solidImage(8, 8, red),
+	})
+
+	tests := []struct {
+		format Format
+		decode func(io.Reader) (image.Image, error)
+	}{
+		{format: FormatPNG, decode: png.Decode},
+		{format: FormatJPEG, decode: jpeg.Decode},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Write(
+				context.Background(), &buf, jobs, tt.format,
+				testOptions(src),
+			)
+			require.NoError(t, err)
+
+			img, err := tt.decode(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, image.Rect(0, 0, 92, 112), img.Bounds())
+		})
+	}
+
+	err := Write(context.Background(), io.Discard, jobs, "gif", nil)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestCaption(t *testing.T) {
+	tests := []struct {
+		name  string
+		job   *midjourney.Job
+		lines int
+		width int
+		want  []string
+	}{
+		{
+			name:  "fits",
+			job:   &midjourney.Job{ID: "abc", Prompt: "a  cat\n"},
+			lines: 1,
+			width: 10,
+			want:  []string{"a cat", "abc"},
+		},
+		{
+			name:  "wraps",
+			job:   &midjourney.Job{ID: "abc", Prompt: "a cat on a mat"},
+			lines: 2,
+			width: 10,
+			want:  []string{"a cat on a", "mat", "abc"},
+		},
+		{
+			name: "truncated",
+			job: &midjourney.Job{
+				ID:     "a3052616-372b-42a1",
+				Prompt: "a cat on a mat, by the sea",
+			},
+			lines: 2,
+			width: 10,
+			want:  []string{"a cat on a", "mat, b...", "a3052616-3"},
+		},
+		{
+			name:  "no prompt",
+			job:   &midjourney.Job{ID: "abc"},
+			lines: 2,
+			width: 10,
+			want:  []string{"abc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := caption(tt.job, tt.lines, tt.width)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type fakeHTTPClient struct {
+	urls []string
+}
+
+func (c *fakeHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.urls = append(c.urls, req.URL.String())
+
+	if !strings.Contains(req.URL.Path, "/ok/") {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Status:     "404 Not Found",
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	}
+
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, solidImage(2, 2, red))
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(&buf),
+	}, nil
+}
+
+func TestThumbnailSource(t *testing.T) {
+	client := &fakeHTTPClient{}
+	src := ThumbnailSource(client, midjourney.ThumbnailSizeSmall)
+
+	img, err := src(context.Background(), &midjourney.Job{ID: "ok"})
+	require.NoError(t, err)
+	assert.Equal(t, red, rgba(img.At(1, 1)))
+
+	_, err = src(context.Background(), &midjourney.Job{ID: "nope"})
+	assert.ErrorIs(t, err, ErrFetch)
+
+	assert.Equal(t, []string{
+		"https://cdn.midjourney.com/ok/grid_0_128_N.png",
+		"https://cdn.midjourney.com/nope/grid_0_128_N.png",
+	}, client.urls)
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+	job := &midjourney.Job{ID: "a", Username: "bob", Prompt: "cat"}
+
+	f, err := os.Create(filepath.Join(dir, job.ImageFilename()))
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(f, solidImage(2, 2, blue)))
+	require.NoError(t, f.Close())
+
+	src := FallbackSource(
+		DirSource(filepath.Join(dir, "missing")),
+		DirSource(dir),
+	)
+
+	img, err := src(context.Background(), job)
+	require.NoError(t, err)
+	assert.Equal(t, blue, rgba(img.At(0, 0)))
+
+	_, err = src(context.Background(), &midjourney.Job{ID: "b"})
+	assert.ErrorIs(t, err, ErrImageNotFound)
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	assert.Equal(t, FormatJPEG, FormatFromFilename("sheet.JPG"))
+	assert.Equal(t, FormatJPEG, FormatFromFilename("sheet.jpeg"))
+	assert.Equal(t, FormatPNG, FormatFromFilename("sheet.png"))
+	assert.Equal(t, FormatPNG, FormatFromFilename("sheet"))
+}
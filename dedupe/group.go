@@ -0,0 +1,144 @@
+package dedupe
+
+import (
+	"sort"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+type Algorithm string
+
+const (
+	AlgorithmAHash Algorithm = "ahash"
+	AlgorithmDHash Algorithm = "dhash"
+)
+
+// DefaultThreshold is a Hamming distance between hashes below which images
+// are usually near-duplicates, like re-rolls of a prompt with the same seed,
+// or upscales of the same image.
+const DefaultThreshold = 10
+
+// Group is a set of jobs with near-identical images.
+type Group struct {
+	// Entries are sorted with the job most worth keeping first: highest
+	// ranked, upscales before grids, and oldest first.
+	Entries []*Entry
+
+	// MaxDistance is the largest distance between the hashes of any two
+	// entries.
+	MaxDistance int
+}
+
+// Keep returns the entry most worth keeping.
+func (g *Group) Keep() *Entry {
+	return g.Entries[0]
+}
+
+// Duplicates returns all entries except the one returned by Keep.
+func (g *Group) Duplicates() []*Entry {
+	return g.Entries[1:]
+}
+
+// JobIDs returns the IDs of all jobs in the group, in the same order as
+// Entries.
+func (g *Group) JobIDs() []string {
+	ids := make([]string, 0, len(g.Entries))
+	for _, e := range g.Entries {
+		ids = append(ids, e.Job.ID)
+	}
+
+	return ids
+}
+
+// Groups returns all groups of two or more jobs whose hashes for the given
+// algorithm are within threshold bits of each other. Jobs are grouped
+// transitively, so a group may contain entries further apart than threshold,
+// as reported by MaxDistance. Groups are sorted by the job ID of their Keep
+// entry.
+func (s *Store) Groups(alg Algorithm, threshold int) []*Group {
+	entries := s.Entries()
+
+	parent := make([]int, len(entries))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	for i := range entries {
+		hi := entries[i].Hash(alg)
+		for j := i + 1; j < len(entries); j++ {
+			if hi.Distance(entries[j].Hash(alg)) <= threshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	members := map[int][]*Entry{}
+	for i, e := range entries {
+		root := find(i)
+		members[root] = append(members[root], e)
+	}
+
+	groups := []*Group{}
+	for _, m := range members {
+		if len(m) < 2 {
+			continue
+		}
+
+		sort.SliceStable(m, func(i, j int) bool {
+			return keepBefore(m[i].Job, m[j].Job)
+		})
+		g := &Group{Entries: m}
+		for i := range m {
+			for j := i + 1; j < len(m); j++ {
+				d := m[i].Hash(alg).Distance(m[j].Hash(alg))
+				if d > g.MaxDistance {
+					g.MaxDistance = d
+				}
+			}
+		}
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Keep().Job.ID < groups[j].Keep().Job.ID
+	})
+
+	return groups
+}
+
+// Duplicates returns the IDs of all jobs which are near-duplicates of a job
+// more worth keeping, as determined by Groups.
+func (s *Store) Duplicates(alg Algorithm, threshold int) []string {
+	ids := []string{}
+	for _, g := range s.Groups(alg, threshold) {
+		for _, e := range g.Duplicates() {
+			ids = append(ids, e.Job.ID)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// keepBefore reports if job a is more worth keeping than b.
+func keepBefore(a, b *midjourney.Job) bool {
+	if a.RankingByUser.Int() != b.RankingByUser.Int() {
+		return a.RankingByUser.Int() > b.RankingByUser.Int()
+	}
+	if (a.Type == midjourney.JobTypeUpscale) !=
+		(b.Type == midjourney.JobTypeUpscale) {
+		return a.Type == midjourney.JobTypeUpscale
+	}
+	if !a.EnqueueTime.Equal(b.EnqueueTime.Time) {
+		return a.EnqueueTime.Before(b.EnqueueTime.Time)
+	}
+
+	return a.ID < b.ID
+}
@@ -0,0 +1,37 @@
+package dedupe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_Groups(t *testing.T) {
+	s := testStore(t)
+
+	for _, alg := range []Algorithm{AlgorithmAHash, AlgorithmDHash} {
+		t.Run(string(alg), func(t *testing.T) {
+			groups := s.Groups(alg, DefaultThreshold)
+
+			require.Len(t, groups, 2)
+			assert.Equal(t,
+				[]string{"loved", "copy"}, groups[0].JobIDs(),
+			)
+			assert.Equal(t, 0, groups[0].MaxDistance)
+			assert.Equal(t,
+				[]string{"upscale", "grid", "reroll"}, groups[1].JobIDs(),
+			)
+			assert.Equal(t, "upscale", groups[1].Keep().Job.ID)
+			assert.Len(t, groups[1].Duplicates(), 2)
+			assert.LessOrEqual(t, groups[1].MaxDistance, DefaultThreshold)
+
+			assert.Equal(t,
+				[]string{"copy", "grid", "reroll"},
+				s.Duplicates(alg, DefaultThreshold),
+			)
+		})
+	}
+
+	assert.Len(t, s.Groups(AlgorithmDHash, -1), 0)
+}
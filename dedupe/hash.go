@@ -0,0 +1,136 @@
+// Package dedupe finds near-duplicate jobs by comparing perceptual hashes of
+// their images.
+package dedupe
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math/bits"
+	"strconv"
+)
+
+var (
+	Err            = errors.New("dedupe")
+	ErrInvalidHash = fmt.Errorf("%w: invalid hash", Err)
+)
+
+// Hash is a 64-bit perceptual hash of an image. Similar images have hashes
+// with a small Hamming distance.
+type Hash uint64
+
+// Distance returns the number of bits which differ between h and other.
+func (h Hash) Distance(other Hash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
+
+// String returns the hash as 16 hexadecimal characters.
+func (h Hash) String() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+func (h Hash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+func (h *Hash) UnmarshalText(b []byte) error {
+	v, err := ParseHash(string(b))
+	if err != nil {
+		return err
+	}
+	*h = v
+
+	return nil
+}
+
+// ParseHash parses a hash as returned by Hash.String.
+func ParseHash(s string) (Hash, error) {
+	if len(s) != 16 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidHash, s)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidHash, s)
+	}
+
+	return Hash(v), nil
+}
+
+// AHash returns the average hash of img: the image is reduced to 8x8
+// grayscale pixels, and each bit is set if the pixel is brighter than the
+// mean.
+func AHash(img image.Image) Hash {
+	px := grayscale(img, 8, 8)
+
+	var mean float64
+	for _, v := range px {
+		mean += v
+	}
+	mean /= float64(len(px))
+
+	var h Hash
+	for i, v := range px {
+		if v > mean {
+			h |= 1 << uint(i)
+		}
+	}
+
+	return h
+}
+
+// DHash returns the difference hash of img: the image is reduced to 9x8
+// grayscale pixels, and each bit is set if a pixel is brighter than its right
+// neighbour. It is more robust than AHash against changes in brightness and
+// contrast.
+func DHash(img image.Image) Hash {
+	px := grayscale(img, 9, 8)
+
+	var h Hash
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if px[y*9+x] > px[y*9+x+1] {
+				h |= 1 << uint(y*8+x)
+			}
+		}
+	}
+
+	return h
+}
+
+// grayscale reduces img to w by h pixels of luminance, averaging all source
+// pixels covered by each destination pixel.
+func grayscale(img image.Image, w, h int) []float64 {
+	b := img.Bounds()
+	px := make([]float64, w*h)
+	if b.Empty() {
+		return px
+	}
+
+	for y := 0; y < h; y++ {
+		y0 := b.Min.Y + y*b.Dy()/h
+		y1 := b.Min.Y + (y+1)*b.Dy()/h
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < w; x++ {
+			x0 := b.Min.X + x*b.Dx()/w
+			x1 := b.Min.X + (x+1)*b.Dx()/w
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var sum float64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, _ := img.At(sx, sy).RGBA()
+					sum += 0.299*float64(cr) + 0.587*float64(cg) +
+						0.114*float64(cb)
+				}
+			}
+			px[y*w+x] = sum / float64((y1-y0)*(x1-x0))
+		}
+	}
+
+	return px
+}
@@ -0,0 +1,108 @@
+package dedupe
+
+import (
+	"encoding/json"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testImage returns a w by h image with a diagonal gradient, and a bright
+// square in the given quadrant.
+func testImage(w, h, quadrant int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8((x*255/w + y*255/h) / 2)
+			if (x*2/w)+(y*2/h)*2 == quadrant {
+				v = 255 - v/4
+			}
+			img.Set(x, y, color.RGBA{R: v, G: v, B: v, A: 255})
+		}
+	}
+
+	return img
+}
+
+// adjust returns a copy of img with brightness shifted by delta, and random
+// noise of up to noise levels added.
+func adjust(img *image.RGBA, delta, noise int) *image.RGBA {
+	r := rand.New(rand.NewSource(1)) //nolint:gosec
+	out := image.NewRGBA(img.Bounds())
+	for i := 0; i < len(img.Pix); i += 4 {
+		for c := 0; c < 3; c++ {
+			v := int(img.Pix[i+c]) + delta
+			if noise > 0 {
+				v += r.Intn(2*noise+1) - noise
+			}
+			if v < 0 {
+				v = 0
+			} else if v > 255 {
+				v = 255
+			}
+			out.Pix[i+c] = uint8(v)
+		}
+		out.Pix[i+3] = 255
+	}
+
+	return out
+}
+
+func TestHashes(t *testing.T) {
+	orig := testImage(128, 128, 0)
+	tests := []struct {
+		name    string
+		img     image.Image
+		similar bool
+	}{
+		{name: "identical", img: testImage(128, 128, 0), similar: true},
+		{name: "resized", img: testImage(512, 512, 0), similar: true},
+		{name: "brighter", img: adjust(orig, 20, 0), similar: true},
+		{name: "noisy", img: adjust(orig, 0, 10), similar: true},
+		{name: "different", img: testImage(128, 128, 3), similar: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, fn := range map[string]func(image.Image) Hash{
+				"ahash": AHash,
+				"dhash": DHash,
+			} {
+				d := fn(orig).Distance(fn(tt.img))
+				if tt.similar {
+					assert.LessOrEqual(t, d, DefaultThreshold, name)
+				} else {
+					assert.Greater(t, d, DefaultThreshold, name)
+				}
+			}
+		})
+	}
+}
+
+func TestHash_Distance(t *testing.T) {
+	assert.Equal(t, 0, Hash(0xff).Distance(0xff))
+	assert.Equal(t, 8, Hash(0xff).Distance(0))
+	assert.Equal(t, 64, Hash(0).Distance(^Hash(0)))
+}
+
+func TestHash_Text(t *testing.T) {
+	h := Hash(0x0123456789abcdef)
+	assert.Equal(t, "0123456789abcdef", h.String())
+
+	b, err := json.Marshal(h)
+	require.NoError(t, err)
+	assert.Equal(t, `"0123456789abcdef"`, string(b))
+
+	var got Hash
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+	assert.Equal(t, h, got)
+
+	for _, s := range []string{"", "123", "0123456789abcdeg"} {
+		_, err = ParseHash(s)
+		assert.ErrorIs(t, err, ErrInvalidHash, s)
+	}
+}
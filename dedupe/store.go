@@ -0,0 +1,168 @@
+package dedupe
+
+import (
+	"encoding/json"
+	"errors"
+	"image"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	// Register decoders for images read by AddReader and AddFile.
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/jimeh/go-midjourney/internal/fileutil"
+)
+
+// Entry is a job along with the perceptual hashes of its image.
+type Entry struct {
+	Job   *midjourney.Job `json:"job"`
+	AHash Hash            `json:"ahash"`
+	DHash Hash            `json:"dhash"`
+}
+
+// Hash returns the entry's hash for the given algorithm.
+func (e *Entry) Hash(alg Algorithm) Hash {
+	if alg == AlgorithmAHash {
+		return e.AHash
+	}
+
+	return e.DHash
+}
+
+// Store holds the hashes of job images. It is safe for concurrent use.
+type Store struct {
+	mux     sync.RWMutex
+	entries map[string]*Entry
+}
+
+// NewStore returns a new empty Store.
+func NewStore() *Store {
+	return &Store{entries: map[string]*Entry{}}
+}
+
+// Len returns the number of jobs in the store.
+func (s *Store) Len() int {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return len(s.entries)
+}
+
+// Entry returns the entry of the job with the given ID, or nil.
+func (s *Store) Entry(jobID string) *Entry {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return s.entries[jobID]
+}
+
+// Entries returns all entries sorted by job ID.
+func (s *Store) Entries() []*Entry {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	entries := make([]*Entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Job.ID < entries[j].Job.ID
+	})
+
+	return entries
+}
+
+// Add hashes img, and adds or replaces the job in the store.
+func (s *Store) Add(job *midjourney.Job, img image.Image) *Entry {
+	e := &Entry{Job: job, AHash: AHash(img), DHash: DHash(img)}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.entries[job.ID] = e
+
+	return e
+}
+
+// AddReader decodes a PNG or JPEG image from r, and adds it like Add.
+func (s *Store) AddReader(job *midjourney.Job, r io.Reader) (*Entry, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Add(job, img), nil
+}
+
+// AddFile decodes the PNG or JPEG image at path, and adds it like Add.
+func (s *Store) AddFile(job *midjourney.Job, path string) (*Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return s.AddReader(job, f)
+}
+
+// Remove removes the given jobs from the store.
+func (s *Store) Remove(jobIDs ...string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, id := range jobIDs {
+		delete(s.entries, id)
+	}
+}
+
+// Save writes all entries to w as JSON lines, sorted by job ID.
+func (s *Store) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, e := range s.Entries() {
+		err := enc.Encode(e)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Load reads a store previously written with Save.
+func Load(r io.Reader) (*Store, error) {
+	s := NewStore()
+
+	dec := json.NewDecoder(r)
+	for {
+		e := &Entry{}
+		err := dec.Decode(e)
+		if errors.Is(err, io.EOF) {
+			return s, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if e.Job != nil && e.Job.ID != "" {
+			s.entries[e.Job.ID] = e
+		}
+	}
+}
+
+// SaveFile writes the store to the given file, replacing it atomically.
+func (s *Store) SaveFile(path string) error {
+	return fileutil.WriteAtomic(path, s.Save)
+}
+
+// Open loads the store from the given file. If the file does not exist, a new
+// empty store is returned.
+func Open(path string) (*Store, error) {
+	s, err := fileutil.Load(path, Load)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewStore(), nil
+	}
+
+	return s, err
+}
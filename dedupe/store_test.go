@@ -0,0 +1,93 @@
+package dedupe
+
+import (
+	"bytes"
+	"image/png"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStore(t *testing.T) *Store {
+	t.Helper()
+
+	at := func(d int) midjourney.Time {
+		return midjourney.Time{
+			Time: time.Date(2022, 12, d, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	s := NewStore()
+	s.Add(&midjourney.Job{
+		ID: "grid", Type: midjourney.JobTypeGrid, EnqueueTime: at(1),
+	}, testImage(128, 128, 0))
+	s.Add(&midjourney.Job{
+		ID: "reroll", Type: midjourney.JobTypeGrid, EnqueueTime: at(2),
+	}, adjust(testImage(128, 128, 0), 5, 5))
+	s.Add(&midjourney.Job{
+		ID: "upscale", Type: midjourney.JobTypeUpscale, EnqueueTime: at(3),
+	}, testImage(256, 256, 0))
+	s.Add(&midjourney.Job{
+		ID: "loved", EnqueueTime: at(4),
+		RankingByUser: midjourney.NewFlexInt(int(midjourney.Loved)),
+	}, testImage(128, 128, 3))
+	s.Add(&midjourney.Job{
+		ID: "copy", EnqueueTime: at(5),
+	}, testImage(128, 128, 3))
+	s.Add(&midjourney.Job{ID: "unique"}, testImage(128, 128, 1))
+
+	return s
+}
+
+func TestStore_SaveLoad(t *testing.T) {
+	s := testStore(t)
+
+	var buf bytes.Buffer
+	err := s.Save(&buf)
+	require.NoError(t, err)
+
+	got, err := Load(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, s.Len(), got.Len())
+	for _, e := range s.Entries() {
+		g := got.Entry(e.Job.ID)
+		require.NotNil(t, g, e.Job.ID)
+		assert.Equal(t, e.AHash, g.AHash)
+		assert.Equal(t, e.DHash, g.DHash)
+		assert.Equal(t, e.Job.Type, g.Job.Type)
+	}
+
+	path := filepath.Join(t.TempDir(), "hashes.jsonl")
+	empty, err := Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, 0, empty.Len())
+
+	err = s.SaveFile(path)
+	require.NoError(t, err)
+	got, err = Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, s.Entries(), got.Entries())
+}
+
+func TestStore_AddReader(t *testing.T) {
+	img := testImage(64, 64, 2)
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	s := NewStore()
+	e, err := s.AddReader(&midjourney.Job{ID: "a"}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, AHash(img), e.AHash)
+	assert.Equal(t, DHash(img), e.DHash)
+	assert.Same(t, e, s.Entry("a"))
+
+	s.Remove("a")
+	assert.Equal(t, 0, s.Len())
+
+	_, err = s.AddReader(&midjourney.Job{ID: "b"}, &bytes.Buffer{})
+	assert.Error(t, err)
+}
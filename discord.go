@@ -0,0 +1,145 @@
+package midjourney
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidDiscordURL = fmt.Errorf("%w: invalid discord url", Err)
+
+// discordEpoch is the Unix time in milliseconds of the first second of 2015,
+// which Discord snowflake IDs are relative to.
+const discordEpoch = 1420070400000
+
+// discordLookupWindow is how long before a Discord message was posted
+// JobByDiscordMessage will look for the job which it belongs to.
+const discordLookupWindow = 24 * time.Hour
+
+// DiscordMessage identifies a message posted to Discord by the Midjourney bot.
+type DiscordMessage struct {
+	GuildID   string
+	ChannelID string
+	MessageID string
+}
+
+// ParseDiscordURL parses a Discord message link, as copied from the Discord
+// app or returned by Job.DiscordURL.
+func ParseDiscordURL(rawURL string) (*DiscordMessage, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDiscordURL, err)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	host = strings.TrimPrefix(host, "ptb.")
+	host = strings.TrimPrefix(host, "canary.")
+	if host != "discord.com" && host != "discordapp.com" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDiscordURL, rawURL)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 4 || parts[0] != "channels" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDiscordURL, rawURL)
+	}
+	for _, p := range parts[1:] {
+		if !isSnowflake(p) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidDiscordURL, rawURL)
+		}
+	}
+
+	return &DiscordMessage{
+		GuildID:   parts[1],
+		ChannelID: parts[2],
+		MessageID: parts[3],
+	}, nil
+}
+
+func isSnowflake(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+
+	return err == nil
+}
+
+// URL returns the link to the message.
+func (m *DiscordMessage) URL() string {
+	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s",
+		m.GuildID,
+		m.ChannelID,
+		m.MessageID,
+	)
+}
+
+// Time returns the time the message was posted, as encoded in its ID.
+func (m *DiscordMessage) Time() time.Time {
+	id, err := strconv.ParseUint(m.MessageID, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(int64(id>>22) + discordEpoch).UTC()
+}
+
+// DiscordMessage returns the Discord message the job was posted in, or nil if
+// the job was not created on Discord.
+func (j *Job) DiscordMessage() *DiscordMessage {
+	if j.Platform != "discord" || j.GuildID == "" ||
+		j.PlatformChannelID == "" || j.PlatformMessageID == "" {
+		return nil
+	}
+
+	return &DiscordMessage{
+		GuildID:   j.GuildID,
+		ChannelID: j.PlatformChannelID,
+		MessageID: j.PlatformMessageID,
+	}
+}
+
+// JobByDiscordMessage returns the job posted in the Discord message at the
+// given link.
+//
+// As jobs cannot be looked up by Discord message directly, the current user's
+// jobs enqueued within a day before the message was posted are searched. Jobs
+// of other users cannot be found.
+func (c *Client) JobByDiscordMessage(
+	ctx context.Context,
+	discordURL string,
+) (*Job, error) {
+	msg, err := ParseDiscordURL(discordURL)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := c.userIDOrMe(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	posted := msg.Time()
+	q := userJobsQuery(userID)
+	q.JobStatus = ""
+	q.FromDate = posted.Add(time.Minute)
+
+	it := c.RecentJobsIter(q)
+	for it.Next(ctx) {
+		j := it.Job()
+		if m := j.DiscordMessage(); m != nil &&
+			m.ChannelID == msg.ChannelID && m.MessageID == msg.MessageID {
+			return j, nil
+		}
+		if !j.EnqueueTime.IsZero() &&
+			j.EnqueueTime.Before(posted.Add(-discordLookupWindow)) {
+			break
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf(
+		"%w: discord message=%s", ErrJobNotFound, msg.MessageID,
+	)
+}
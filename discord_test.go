@@ -0,0 +1,168 @@
+package midjourney
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func snowflake(t time.Time) string {
+	return strconv.FormatUint(
+		uint64(t.UnixMilli()-discordEpoch)<<22, 10,
+	)
+}
+
+func TestParseDiscordURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    *DiscordMessage
+		wantErr error
+	}{
+		{
+			name: "discord.com",
+			url:  "https://discord.com/channels/111/222/333",
+			want: &DiscordMessage{
+				GuildID: "111", ChannelID: "222", MessageID: "333",
+			},
+		},
+		{
+			name: "canary discordapp.com with trailing slash",
+			url:  " https://canary.discordapp.com/channels/111/222/333/ ",
+			want: &DiscordMessage{
+				GuildID: "111", ChannelID: "222", MessageID: "333",
+			},
+		},
+		{
+			name:    "channel link",
+			url:     "https://discord.com/channels/111/222",
+			wantErr: ErrInvalidDiscordURL,
+		},
+		{
+			name:    "non-numeric id",
+			url:     "https://discord.com/channels/@me/222/333",
+			wantErr: ErrInvalidDiscordURL,
+		},
+		{
+			name:    "other host",
+			url:     "https://example.com/channels/111/222/333",
+			wantErr: ErrInvalidDiscordURL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDiscordURL(tt.url)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiscordMessage_Time(t *testing.T) {
+	// Example from the Discord API documentation.
+	m := &DiscordMessage{MessageID: "175928847299117063"}
+
+	assert.Equal(t,
+		time.Date(2016, 4, 30, 11, 18, 25, 796000000, time.UTC), m.Time(),
+	)
+}
+
+func TestJob_DiscordURL_RoundTrip(t *testing.T) {
+	j := &Job{
+		Platform:          "discord",
+		GuildID:           "111",
+		PlatformChannelID: "222",
+		PlatformMessageID: "333",
+	}
+
+	got, err := ParseDiscordURL(j.DiscordURL())
+	require.NoError(t, err)
+
+	assert.Equal(t, j.DiscordMessage(), got)
+	assert.Nil(t, (&Job{GuildID: "111"}).DiscordMessage())
+}
+
+// fakeDiscordJobsServer implements app/me/, and app/recent-jobs returning jobs
+// enqueued before the requested fromDate.
+type fakeDiscordJobsServer struct {
+	*fakeServer
+	jobs    []*Job
+	queries []*RecentJobsQuery
+}
+
+func newFakeDiscordJobsServer(jobs ...*Job) *fakeDiscordJobsServer {
+	s := &fakeDiscordJobsServer{fakeServer: newFakeServer(), jobs: jobs}
+	s.handle("/app/me/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "u1"}`))
+	})
+	s.handle("/app/recent-jobs", recentJobsRoute(
+		func(q *RecentJobsQuery) []*Job {
+			s.queries = append(s.queries, q)
+
+			jobs := []*Job{}
+			if q.Page == 0 {
+				for _, j := range s.jobs {
+					if j.EnqueueTime.Before(q.FromDate) {
+						jobs = append(jobs, j)
+					}
+				}
+			}
+
+			return jobs
+		},
+	))
+
+	return s
+}
+
+func TestClient_JobByDiscordMessage(t *testing.T) {
+	ctx := context.Background()
+	posted := time.Date(2022, 9, 7, 7, 0, 0, 0, time.UTC)
+	msgID := snowflake(posted)
+
+	discordJob := func(id, msgID string, enqueued time.Time) *Job {
+		return &Job{
+			ID:                id,
+			EnqueueTime:       Time{Time: enqueued},
+			Platform:          "discord",
+			GuildID:           "111",
+			PlatformChannelID: "222",
+			PlatformMessageID: msgID,
+		}
+	}
+	srv := newFakeDiscordJobsServer(
+		discordJob("later", "1", posted.Add(time.Hour)),
+		discordJob("other", "2", posted.Add(-time.Minute)),
+		discordJob("match", msgID, posted.Add(-2*time.Minute)),
+	)
+	c := newTestClient(t, srv)
+
+	got, err := c.JobByDiscordMessage(
+		ctx, "https://discord.com/channels/111/222/"+msgID,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "match", got.ID)
+	require.Len(t, srv.queries, 1)
+	assert.Equal(t, "u1", srv.queries[0].UserID)
+	assert.Equal(t, posted.Add(time.Minute), srv.queries[0].FromDate)
+
+	_, err = c.JobByDiscordMessage(
+		ctx, "https://discord.com/channels/111/222/"+
+			snowflake(posted.Add(48*time.Hour)),
+	)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+
+	_, err = c.JobByDiscordMessage(ctx, "https://example.com/")
+	assert.ErrorIs(t, err, ErrInvalidDiscordURL)
+}
@@ -0,0 +1,109 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report is the result of checking a single endpoint.
+type Report struct {
+	Endpoint string    `json:"endpoint"`
+	Skipped  string    `json:"skipped,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	Changes  []*Change `json:"changes,omitempty"`
+}
+
+// OK returns true if the endpoint was checked without error or changes.
+func (r *Report) OK() bool {
+	return r.Skipped == "" && r.Error == "" && len(r.Changes) == 0
+}
+
+// Check fetches a sample response for each endpoint from src, and compares its
+// shape against the shape the endpoint expects. When env is nil, values needed
+// by endpoints are only learned from earlier responses.
+//
+// Failures to fetch or parse individual samples are recorded in the returned
+// reports. An error is only returned if ctx is done.
+func Check(
+	ctx context.Context,
+	src Source,
+	endpoints []*Endpoint,
+	env *Env,
+) ([]*Report, error) {
+	if env == nil {
+		env = &Env{}
+	}
+
+	reports := make([]*Report, 0, len(endpoints))
+	for _, e := range endpoints {
+		if err := ctx.Err(); err != nil {
+			return reports, err
+		}
+
+		r := &Report{Endpoint: e.Name}
+		reports = append(reports, r)
+
+		data, err := src.Sample(ctx, e, env)
+		if errors.Is(err, ErrSkipped) {
+			r.Skipped = strings.TrimPrefix(err.Error(), ErrSkipped.Error()+": ")
+
+			continue
+		} else if err != nil {
+			r.Error = err.Error()
+
+			continue
+		}
+
+		if e.Learn != nil {
+			e.Learn(data, env)
+		}
+
+		actual, err := Infer(data)
+		if err != nil {
+			r.Error = err.Error()
+
+			continue
+		}
+		r.Changes = Compare(e.Expected, actual)
+	}
+
+	return reports, nil
+}
+
+// Drifted returns true if any report contains changes or errors.
+func Drifted(reports []*Report) bool {
+	for _, r := range reports {
+		if r.Error != "" || len(r.Changes) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteText writes a human readable summary of reports to w.
+func WriteText(w io.Writer, reports []*Report) error {
+	var b strings.Builder
+	for _, r := range reports {
+		switch {
+		case r.Skipped != "":
+			fmt.Fprintf(&b, "%s: skipped: %s\n", r.Endpoint, r.Skipped)
+		case r.Error != "":
+			fmt.Fprintf(&b, "%s: error: %s\n", r.Endpoint, r.Error)
+		case len(r.Changes) == 0:
+			fmt.Fprintf(&b, "%s: ok\n", r.Endpoint)
+		default:
+			fmt.Fprintf(&b, "%s: %d changes\n", r.Endpoint, len(r.Changes))
+			for _, c := range r.Changes {
+				fmt.Fprintf(&b, "  %s\n", c)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
@@ -0,0 +1,266 @@
+package drift
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	ID    string            `json:"id"`
+	Count int               `json:"count,omitempty"`
+	Tags  []string          `json:"tags,omitempty"`
+	Time  midjourney.Time   `json:"time,omitempty"`
+	Meta  map[string]string `json:"meta,omitempty"`
+	Skip  string            `json:"-"`
+}
+
+func TestShapeOf(t *testing.T) {
+	s := ShapeOf([]*testItem{})
+
+	assert.Equal(t, "array<object>", s.String())
+	assert.Equal(t, []string{"count", "id", "meta", "tags", "time"},
+		sortedKeys(s.Elem.Fields),
+	)
+	assert.Equal(t, "string", s.Elem.Fields["time"].String())
+	assert.Equal(t, "map<string>", s.Elem.Fields["meta"].String())
+	assert.Equal(t, "array<string>", s.Elem.Fields["tags"].String())
+}
+
+func TestShapeOf_Embedded(t *testing.T) {
+	s := ShapeOf(&midjourney.Account{})
+
+	assert.Contains(t, s.Fields, "id")
+	assert.Contains(t, s.Fields, "username")
+	assert.Contains(t, s.Fields, "subscription")
+	assert.NotContains(t, s.Fields, "User")
+}
+
+func TestShapeOf_Job(t *testing.T) {
+	s := ShapeOf(&midjourney.Job{})
+
+	assert.Equal(t, KindObject, s.Kind)
+	assert.NotContains(t, s.Fields, "Extra")
+	assert.Equal(t, KindString, s.Fields["enqueue_time"].Kind)
+	assert.Equal(t, KindObject, s.Fields["event"].Kind)
+	assert.Contains(t, s.Fields["event"].Fields, "textPrompt")
+	assert.Equal(t, KindAny, s.Fields["_parsed_params"].Fields["version"].Kind)
+}
+
+func TestInfer(t *testing.T) {
+	s, err := Infer([]byte(`[
+		{"id": "a", "count": 1, "tags": []},
+		{"id": "b", "tags": ["x"], "extra": null},
+		{"id": "c", "extra": true}
+	]`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "array<object>", s.String())
+	assert.Equal(t, "number", s.Elem.Fields["count"].String())
+	assert.Equal(t, "array<string>", s.Elem.Fields["tags"].String())
+	assert.Equal(t, "boolean", s.Elem.Fields["extra"].String())
+
+	_, err = Infer([]byte(`{`))
+	assert.ErrorIs(t, err, Err)
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []*Change
+	}{
+		{
+			name: "match",
+			data: `[{"id":"a","count":1,"tags":["x"],"time":"2022-01-01",` +
+				`"meta":{"a":"b"}}]`,
+			want: nil,
+		},
+		{
+			name: "nulls and empty arrays",
+			data: `[{"id":null,"count":null,"tags":[],"time":null,` +
+				`"meta":{}}]`,
+			want: nil,
+		},
+		{
+			name: "added removed and type changed",
+			data: `[{"id":1,"tags":[2],"time":"x","meta":{"a":"b"},` +
+				`"new":{"x":1}}]`,
+			want: []*Change{
+				{
+					Path: "$[].count", Kind: Removed, Expected: "number",
+				},
+				{
+					Path: "$[].id", Kind: TypeChanged,
+					Expected: "string", Actual: "number",
+				},
+				{Path: "$[].new", Kind: Added, Actual: "object"},
+				{
+					Path: "$[].tags[]", Kind: TypeChanged,
+					Expected: "string", Actual: "number",
+				},
+			},
+		},
+		{
+			name: "map values",
+			data: `[{"id":"a","count":1,"tags":[],"time":null,` +
+				`"meta":{"a":"b","c":1}}]`,
+			want: []*Change{
+				{
+					Path: "$[].meta{}", Kind: TypeChanged,
+					Expected: "string", Actual: "any",
+				},
+			},
+		},
+		{
+			name: "root type changed",
+			data: `{"id":"a"}`,
+			want: []*Change{
+				{
+					Path: "$", Kind: TypeChanged,
+					Expected: "array<object>", Actual: "object",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Infer([]byte(tt.data))
+			require.NoError(t, err)
+
+			got := Compare(ShapeOf([]*testItem{}), actual)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	names := map[string]bool{}
+	for _, e := range Endpoints() {
+		assert.False(t, names[e.Name], "duplicate endpoint %s", e.Name)
+		names[e.Name] = true
+
+		require.NotNil(t, e.Expected, e.Name)
+		if !e.Mutating {
+			assert.NotNil(t, e.Request, e.Name)
+		}
+	}
+}
+
+func TestCheck_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	responses := map[string]string{
+		"/app/recent-jobs": `[
+			{"id":"j1","user_id":"u1","prompt":"a cat","new_field":1}
+		]`,
+		"/app/job-status/":  `[{"id":"j1","user_id":"u1"}]`,
+		"/app/collections/": `[{"id":"c1","title":"Cats"}]`,
+		"/app/following/":   `[{"id":"u2","username":"bob"}]`,
+		"/app/me/":          `{"id":"u1","username":"alice"}`,
+		"/app/user/":        `{"id":"u1","username":"alice"}`,
+		"/app/words/":       `{"cat":"j1","dog":2}`,
+		"/app/archive/day":  `["j1","j2"]`,
+	}
+	var gotJobIDs []string
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/app/job-status/" {
+				var body struct {
+					JobIDs []string `json:"jobIds"`
+				}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				gotJobIDs = body.JobIDs
+			}
+			if r.URL.Path == "/app/collections/" {
+				assert.Equal(t, "u1", r.URL.Query().Get("user_id"))
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(responses[r.URL.Path]))
+		},
+	))
+	t.Cleanup(ts.Close)
+
+	client, err := midjourney.New(
+		midjourney.WithAPIURL(ts.URL), midjourney.WithAuthToken("token"),
+	)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	src := &Recorder{Source: &APISource{API: client.API}, Dir: dir}
+
+	live, err := Check(ctx, src, Endpoints(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"j1"}, gotJobIDs)
+	assert.True(t, Drifted(live))
+
+	byName := map[string]*Report{}
+	for _, r := range live {
+		byName[r.Endpoint] = r
+	}
+	assert.Equal(t, "mutating endpoint", byName["collections-jobs"].Skipped)
+	assert.Contains(t, byName["recent-jobs"].Changes,
+		&Change{Path: "$[].new_field", Kind: Added, Actual: "number"},
+	)
+	assert.Equal(t, []*Change{
+		{
+			Path: "${}", Kind: TypeChanged,
+			Expected: "string", Actual: "any",
+		},
+	}, byName["words"].Changes)
+	assert.Empty(t, byName["archive-day"].Changes)
+	assert.Empty(t, byName["archive-day"].Error)
+
+	_, err = os.Stat(filepath.Join(dir, "recent-jobs.json"))
+	require.NoError(t, err)
+
+	err = os.WriteFile(
+		filepath.Join(dir, "collections-jobs.json"),
+		[]byte(`{"success":true,"successes":["j1"],"failures":"none"}`),
+		0o600,
+	)
+	require.NoError(t, err)
+
+	offline, err := Check(ctx, DirSource(dir), Endpoints(), nil)
+	require.NoError(t, err)
+
+	require.Len(t, offline, len(live))
+	for i, r := range offline {
+		if r.Endpoint == "collections-jobs" {
+			assert.Equal(t, []*Change{
+				{
+					Path: "$.failures", Kind: TypeChanged,
+					Expected: "array<string>", Actual: "string",
+				},
+			}, r.Changes)
+
+			continue
+		}
+		if live[i].Skipped != "" {
+			assert.Equal(t, "no fixture", r.Skipped, r.Endpoint)
+
+			continue
+		}
+		assert.Equal(t, live[i], r, r.Endpoint)
+	}
+
+	var buf bytes.Buffer
+	err = WriteText(&buf, offline)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "collection-update: skipped: no fixture\n")
+	assert.Contains(t, buf.String(),
+		"  type changed $.failures (array<string> -> string)\n",
+	)
+	assert.Contains(t, buf.String(), "archive-day: ok\n")
+}
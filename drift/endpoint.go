@@ -0,0 +1,270 @@
+package drift
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+// Env holds values used to build requests for endpoints which depend on
+// existing users, jobs or dates.
+type Env struct {
+	UserID string
+	JobIDs []string
+	Date   time.Time
+}
+
+// Request describes a single API request.
+type Request struct {
+	Method string
+	Path   string
+	Params url.Values
+	Body   any
+}
+
+// Endpoint is an API endpoint used by midjourney.Client, along with the shape
+// of the response it expects.
+type Endpoint struct {
+	// Name identifies the endpoint, and is used as the fixture file name.
+	Name string
+
+	// Expected is the shape of the type responses are decoded into.
+	Expected *Shape
+
+	// Mutating endpoints modify data, and are never requested live. They can
+	// only be checked against recorded fixtures.
+	Mutating bool
+
+	// Request returns the request used to fetch a sample response, or nil if
+	// env lacks the values needed to build one.
+	Request func(env *Env) *Request
+
+	// Learn optionally records values from a sample response in env, for use
+	// by endpoints checked afterwards.
+	Learn func(data []byte, env *Env)
+}
+
+// Endpoints returns all endpoints used by midjourney.Client, ordered so that
+// endpoints which learn values needed by others come first.
+func Endpoints() []*Endpoint {
+	return []*Endpoint{
+		{
+			Name:     "me",
+			Expected: ShapeOf(&midjourney.Account{}),
+			Request: func(env *Env) *Request {
+				return &Request{Method: http.MethodGet, Path: "app/me/"}
+			},
+			Learn: func(data []byte, env *Env) {
+				var u midjourney.User
+				if json.Unmarshal(data, &u) == nil && env.UserID == "" {
+					env.UserID = u.ID
+				}
+			},
+		},
+		{
+			Name:     "recent-jobs",
+			Expected: ShapeOf([]*midjourney.Job{}),
+			Request: func(env *Env) *Request {
+				q := &midjourney.RecentJobsQuery{
+					Amount:    50,
+					JobType:   midjourney.JobTypeNull,
+					OrderBy:   midjourney.OrderNew,
+					JobStatus: midjourney.JobStatusCompleted,
+					UserID:    env.UserID,
+				}
+
+				return &Request{
+					Method: http.MethodGet,
+					Path:   "app/recent-jobs",
+					Params: q.URLValues(),
+				}
+			},
+			Learn: learnJobs,
+		},
+		{
+			Name:     "job-status",
+			Expected: ShapeOf([]*midjourney.Job{}),
+			Request: func(env *Env) *Request {
+				if len(env.JobIDs) == 0 {
+					return nil
+				}
+
+				return &Request{
+					Method: http.MethodPost,
+					Path:   "app/job-status/",
+					Body:   map[string][]string{"jobIds": env.JobIDs},
+				}
+			},
+		},
+		{
+			Name:     "collections",
+			Expected: ShapeOf([]*midjourney.Collection{}),
+			Request: func(env *Env) *Request {
+				if env.UserID == "" {
+					return nil
+				}
+				q := &midjourney.CollectionsQuery{
+					UserID:        env.UserID,
+					IncludeHidden: true,
+				}
+
+				return &Request{
+					Method: http.MethodGet,
+					Path:   "app/collections/",
+					Params: q.URLValues(),
+				}
+			},
+		},
+		{
+			Name:     "collection-update",
+			Expected: ShapeOf(&midjourney.Collection{}),
+			Mutating: true,
+		},
+		{
+			Name:     "collections-jobs",
+			Expected: ShapeOf(&midjourney.CollectionJobsResult{}),
+			Mutating: true,
+		},
+		{
+			Name:     "rank-job",
+			Expected: actionShape(),
+			Mutating: true,
+		},
+		{
+			Name:     "like-job",
+			Expected: actionShape(),
+			Mutating: true,
+		},
+		{
+			Name:     "user",
+			Expected: ShapeOf(&midjourney.User{}),
+			Request: func(env *Env) *Request {
+				if env.UserID == "" {
+					return nil
+				}
+
+				return &Request{
+					Method: http.MethodGet,
+					Path:   "app/user/",
+					Params: url.Values{"user_id": []string{env.UserID}},
+				}
+			},
+		},
+		{
+			Name:     "hide-job",
+			Expected: actionShape(),
+			Mutating: true,
+		},
+		{
+			Name:     "publish-job",
+			Expected: actionShape(),
+			Mutating: true,
+		},
+		{
+			Name:     "following",
+			Expected: ShapeOf([]*midjourney.User{}),
+			Request: func(env *Env) *Request {
+				return &Request{
+					Method: http.MethodGet,
+					Path:   "app/following/",
+				}
+			},
+		},
+		{
+			Name:     "follow-user",
+			Expected: actionShape(),
+			Mutating: true,
+		},
+		{
+			Name:     "submit-job",
+			Expected: submitShape(),
+			Mutating: true,
+		},
+		{
+			Name:     "words",
+			Expected: ShapeOf(map[string]string{}),
+			Request: func(env *Env) *Request {
+				q := &midjourney.WordsQuery{Amount: 50}
+
+				return &Request{
+					Method: http.MethodGet,
+					Path:   "app/words/",
+					Params: q.URLValues(),
+				}
+			},
+		},
+		{
+			Name:     "archive-day",
+			Expected: ShapeOf([]string{}),
+			Request: func(env *Env) *Request {
+				date := env.Date
+				if date.IsZero() {
+					date = time.Now().UTC().AddDate(0, 0, -1)
+				}
+
+				return &Request{
+					Method: http.MethodGet,
+					Path:   "app/archive/day",
+					Params: url.Values{
+						"day":   []string{strconv.Itoa(date.Day())},
+						"month": []string{strconv.Itoa(int(date.Month()))},
+						"year":  []string{strconv.Itoa(date.Year())},
+					},
+				}
+			},
+		},
+	}
+}
+
+// submitShape returns the expected shape of responses from the
+// app/submit-job/ endpoint.
+func submitShape() *Shape {
+	job := ShapeOf(&midjourney.Job{})
+	job.Optional = true
+
+	return &Shape{
+		Kind: KindObject,
+		Fields: map[string]*Shape{
+			"job":   job,
+			"error": {Kind: KindString, Optional: true},
+		},
+	}
+}
+
+// actionShape returns the expected shape of responses from endpoints which
+// perform an action, like ranking a job.
+func actionShape() *Shape {
+	return &Shape{
+		Kind: KindObject,
+		Fields: map[string]*Shape{
+			"success": {Kind: KindBool},
+			"error":   {Kind: KindString, Optional: true},
+		},
+	}
+}
+
+// maxLearnedJobIDs limits the number of job IDs requested from job-status.
+const maxLearnedJobIDs = 10
+
+func learnJobs(data []byte, env *Env) {
+	var jobs []struct {
+		ID     string `json:"id"`
+		UserID string `json:"user_id"`
+	}
+	if json.Unmarshal(data, &jobs) != nil {
+		return
+	}
+
+	for _, j := range jobs {
+		if env.UserID == "" {
+			env.UserID = j.UserID
+		}
+		if j.ID != "" && len(env.JobIDs) < maxLearnedJobIDs {
+			env.JobIDs = append(env.JobIDs, j.ID)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+// Package drift detects changes in the shape of MidJourney API responses
+// compared to the types this module decodes them into.
+package drift
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+var Err = errors.New("drift")
+
+type Kind string
+
+const (
+	KindAny    Kind = "any"
+	KindNull   Kind = "null"
+	KindBool   Kind = "boolean"
+	KindNumber Kind = "number"
+	KindString Kind = "string"
+	KindArray  Kind = "array"
+	KindObject Kind = "object"
+
+	// KindMap is an object with arbitrary keys, and values of the same shape.
+	KindMap Kind = "map"
+)
+
+// Shape describes the structure of a JSON value.
+type Shape struct {
+	Kind Kind `json:"kind"`
+
+	// Elem is the shape of array elements and map values.
+	Elem *Shape `json:"elem,omitempty"`
+
+	// Fields are the shapes of object fields.
+	Fields map[string]*Shape `json:"fields,omitempty"`
+
+	// Optional fields are not reported as removed when absent.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// String returns a short description of the shape, like "array<object>".
+func (s *Shape) String() string {
+	if s == nil {
+		return string(KindAny)
+	}
+	if (s.Kind == KindArray || s.Kind == KindMap) && s.Elem != nil {
+		return fmt.Sprintf("%s<%s>", s.Kind, s.Elem)
+	}
+
+	return string(s.Kind)
+}
+
+// Infer returns the shape of the given JSON document. Shapes of array elements
+// and map values are merged, so a single sample with many elements gives a
+// more complete picture.
+func Infer(data []byte) (*Shape, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var v any
+	err := d.Decode(&v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", Err, err)
+	}
+
+	return inferValue(v), nil
+}
+
+func inferValue(v any) *Shape {
+	switch v := v.(type) {
+	case nil:
+		return &Shape{Kind: KindNull}
+	case bool:
+		return &Shape{Kind: KindBool}
+	case json.Number:
+		return &Shape{Kind: KindNumber}
+	case string:
+		return &Shape{Kind: KindString}
+	case []any:
+		s := &Shape{Kind: KindArray}
+		for _, e := range v {
+			s.Elem = merge(s.Elem, inferValue(e))
+		}
+
+		return s
+	case map[string]any:
+		s := &Shape{Kind: KindObject, Fields: map[string]*Shape{}}
+		for k, e := range v {
+			s.Fields[k] = inferValue(e)
+		}
+
+		return s
+	default:
+		return &Shape{Kind: KindAny}
+	}
+}
+
+// merge returns a shape which describes values of both a and b.
+func merge(a, b *Shape) *Shape {
+	switch {
+	case a == nil || a.Kind == KindNull:
+		return b
+	case b == nil || b.Kind == KindNull:
+		return a
+	case a.Kind != b.Kind:
+		return &Shape{Kind: KindAny}
+	}
+
+	s := &Shape{Kind: a.Kind, Elem: merge(a.Elem, b.Elem)}
+	if a.Kind == KindObject {
+		s.Fields = map[string]*Shape{}
+		for k, f := range a.Fields {
+			s.Fields[k] = f
+		}
+		for k, f := range b.Fields {
+			s.Fields[k] = merge(s.Fields[k], f)
+		}
+	}
+
+	return s
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	rawMessageType      = reflect.TypeOf(json.RawMessage{})
+
+	// knownShapes are the shapes of types with custom JSON decoding.
+	knownShapes = map[reflect.Type]*Shape{
+		reflect.TypeOf(midjourney.Time{}): {Kind: KindString},
+	}
+)
+
+// ShapeOf returns the JSON shape expected when decoding into a value of the
+// same type as v.
+func ShapeOf(v any) *Shape {
+	return shapeOfType(reflect.TypeOf(v), map[reflect.Type]bool{})
+}
+
+func shapeOfType(t reflect.Type, seen map[reflect.Type]bool) *Shape {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t == rawMessageType {
+		return &Shape{Kind: KindAny}
+	}
+	if s, ok := knownShapes[t]; ok {
+		return s
+	}
+
+	// Types with custom decoding are opaque, unless they are structs which
+	// retain unknown fields in an Extra field.
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) &&
+		!hasExtraField(t) {
+		return &Shape{Kind: KindAny}
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return &Shape{Kind: KindBool}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return &Shape{Kind: KindNumber}
+	case reflect.String:
+		return &Shape{Kind: KindString}
+	case reflect.Slice, reflect.Array:
+		return &Shape{Kind: KindArray, Elem: shapeOfType(t.Elem(), seen)}
+	case reflect.Map:
+		return &Shape{Kind: KindMap, Elem: shapeOfType(t.Elem(), seen)}
+	case reflect.Struct:
+		if seen[t] {
+			return &Shape{Kind: KindObject}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		s := &Shape{Kind: KindObject, Fields: map[string]*Shape{}}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+
+			// Fields of untagged embedded structs are promoted.
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+				for k, v := range shapeOfType(ft, seen).Fields {
+					if _, ok := s.Fields[k]; !ok {
+						s.Fields[k] = v
+					}
+				}
+
+				continue
+			}
+
+			if name == "" {
+				name = f.Name
+			}
+			s.Fields[name] = shapeOfType(f.Type, seen)
+		}
+
+		return s
+	default:
+		return &Shape{Kind: KindAny}
+	}
+}
+
+func hasExtraField(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	f, ok := t.FieldByName("Extra")
+
+	return ok && f.Type == reflect.TypeOf(map[string]json.RawMessage{})
+}
+
+type ChangeKind string
+
+const (
+	// Added fields are present in responses, but not expected.
+	Added ChangeKind = "added"
+
+	// Removed fields are expected, but were not present in any response.
+	Removed ChangeKind = "removed"
+
+	// TypeChanged values are present, but of a different type than expected.
+	TypeChanged ChangeKind = "type_changed"
+)
+
+// Change is a single difference between an expected and actual shape.
+type Change struct {
+	Path     string     `json:"path"`
+	Kind     ChangeKind `json:"kind"`
+	Expected string     `json:"expected,omitempty"`
+	Actual   string     `json:"actual,omitempty"`
+}
+
+func (c *Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("added %s (%s)", c.Path, c.Actual)
+	case Removed:
+		return fmt.Sprintf("removed %s (%s)", c.Path, c.Expected)
+	case TypeChanged:
+		return fmt.Sprintf(
+			"type changed %s (%s -> %s)", c.Path, c.Expected, c.Actual,
+		)
+	default:
+		return fmt.Sprintf("%s %s", c.Kind, c.Path)
+	}
+}
+
+// Compare returns all differences between the expected and actual shapes,
+// sorted by path. Null values match any expected shape, and empty arrays match
+// any expected element shape.
+func Compare(expected, actual *Shape) []*Change {
+	changes := compare("$", expected, actual, nil)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+func compare(path string, exp, act *Shape, changes []*Change) []*Change {
+	if exp == nil || act == nil ||
+		exp.Kind == KindAny || act.Kind == KindNull {
+		return changes
+	}
+
+	// Maps are decoded from JSON objects, whose field values are merged to
+	// compare them against the expected value shape.
+	if exp.Kind == KindMap && act.Kind == KindObject {
+		var values *Shape
+		for _, f := range act.Fields {
+			values = merge(values, f)
+		}
+
+		return compare(path+"{}", exp.Elem, values, changes)
+	}
+
+	if exp.Kind != act.Kind {
+		return append(changes, &Change{
+			Path:     path,
+			Kind:     TypeChanged,
+			Expected: exp.String(),
+			Actual:   act.String(),
+		})
+	}
+
+	switch exp.Kind { //nolint:exhaustive
+	case KindArray:
+		return compare(path+"[]", exp.Elem, act.Elem, changes)
+	case KindMap:
+		return compare(path+"{}", exp.Elem, act.Elem, changes)
+	case KindObject:
+		for _, k := range sortedKeys(exp.Fields, act.Fields) {
+			e, inExp := exp.Fields[k]
+			a, inAct := act.Fields[k]
+			p := path + "." + k
+
+			switch {
+			case !inExp:
+				changes = append(changes, &Change{
+					Path: p, Kind: Added, Actual: a.String(),
+				})
+			case !inAct && e.Optional:
+			case !inAct:
+				changes = append(changes, &Change{
+					Path: p, Kind: Removed, Expected: e.String(),
+				})
+			default:
+				changes = compare(p, e, a, changes)
+			}
+		}
+	}
+
+	return changes
+}
+
+func sortedKeys(maps ...map[string]*Shape) []string {
+	seen := map[string]bool{}
+	keys := []string{}
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
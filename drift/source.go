@@ -0,0 +1,108 @@
+package drift
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+// ErrSkipped is returned by sources which cannot provide a sample response for
+// an endpoint.
+var ErrSkipped = fmt.Errorf("%w: skipped", Err)
+
+// Source provides sample responses for endpoints.
+type Source interface {
+	Sample(ctx context.Context, e *Endpoint, env *Env) ([]byte, error)
+}
+
+// APISource fetches sample responses from the live API.
+type APISource struct {
+	API *midjourney.APIClient
+}
+
+func (s *APISource) Sample(
+	ctx context.Context,
+	e *Endpoint,
+	env *Env,
+) ([]byte, error) {
+	if e.Mutating || e.Request == nil {
+		return nil, fmt.Errorf("%w: mutating endpoint", ErrSkipped)
+	}
+
+	req := e.Request(env)
+	if req == nil {
+		return nil, fmt.Errorf("%w: no user or job IDs known", ErrSkipped)
+	}
+
+	var raw json.RawMessage
+	err := s.API.Request(
+		ctx, req.Method, req.Path, req.Params, req.Body, &raw,
+	)
+
+	return raw, err
+}
+
+// DirSource reads recorded sample responses from "<name>.json" files within a
+// directory.
+type DirSource string
+
+func (s DirSource) Sample(
+	_ context.Context,
+	e *Endpoint,
+	_ *Env,
+) ([]byte, error) {
+	b, err := os.ReadFile(fixturePath(string(s), e))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: no fixture", ErrSkipped)
+	}
+
+	return b, err
+}
+
+// Recorder writes all sample responses provided by Source as fixtures to Dir,
+// in a format readable by DirSource.
+type Recorder struct {
+	Source Source
+	Dir    string
+}
+
+func (r *Recorder) Sample(
+	ctx context.Context,
+	e *Endpoint,
+	env *Env,
+) ([]byte, error) {
+	b, err := r.Source.Sample(ctx, e, env)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = json.Indent(&buf, b, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+
+	err = os.MkdirAll(r.Dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(fixturePath(r.Dir, e), buf.Bytes(), 0o644) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func fixturePath(dir string, e *Endpoint) string {
+	return filepath.Join(dir, e.Name+".json")
+}
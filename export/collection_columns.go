@@ -0,0 +1,106 @@
+package export
+
+import (
+	"github.com/jimeh/go-midjourney"
+)
+
+type (
+	col       = midjourney.Collection
+	colColumn = Column[col]
+	filters   = midjourney.CollectionFilters
+)
+
+// filtersColumn wraps a column of the collection's data filters, which are only
+// created when importing a non-empty value.
+func filtersColumn(c *Column[filters]) *colColumn {
+	return &colColumn{
+		Name: "filters_" + c.Name,
+		Get: func(v *col) string {
+			if v.Data == nil || v.Data.Filters == nil {
+				return ""
+			}
+
+			return c.Get(v.Data.Filters)
+		},
+		Set: func(v *col, s string) error {
+			if s == "" {
+				return nil
+			}
+			if v.Data == nil {
+				v.Data = &midjourney.CollectionData{}
+			}
+			if v.Data.Filters == nil {
+				v.Data.Filters = &filters{}
+			}
+
+			return c.Set(v.Data.Filters, s)
+		},
+	}
+}
+
+// CollectionColumns are all available CSV columns for collections.
+var CollectionColumns = []*colColumn{
+	stringColumn("id", func(v *col) *string { return &v.ID }),
+	stringColumn("title", func(v *col) *string { return &v.Title }),
+	stringColumn("description",
+		func(v *col) *string { return &v.Description },
+	),
+	{
+		Name: "created",
+		Get:  func(v *col) string { return formatTime(v.Created) },
+		Set: func(v *col, s string) error {
+			if s == "" {
+				return nil
+			}
+			v.Created = &midjourney.Time{}
+
+			return parseTime("created", s, v.Created)
+		},
+	},
+	stringColumn("creator_id", func(v *col) *string { return &v.CreatorID }),
+	stringColumn("creator_username",
+		func(v *col) *string { return &v.CreatorUsername },
+	),
+	stringColumn("creator_avatar_job_id",
+		func(v *col) *string { return &v.CreatorAvatarJobID },
+	),
+	stringColumn("creator_cover_job_id",
+		func(v *col) *string { return &v.CreatorCoverJobID },
+	),
+	stringColumn("cover_job_id",
+		func(v *col) *string { return &v.CoverJobID },
+	),
+	flexIntColumn("num_jobs",
+		func(v *col) **midjourney.FlexInt { return &v.NumJobs },
+	),
+	boolColumn("hidden", func(v *col) *bool { return &v.Hidden }),
+	boolColumn("public", func(v *col) *bool { return &v.Public }),
+	boolColumn("public_editable",
+		func(v *col) *bool { return &v.PublicEditable },
+	),
+	listColumn("search_terms",
+		func(v *col) *[]string { return &v.SearchTerms },
+	),
+	listColumn("workspaces",
+		func(v *col) *[]string { return &v.Workspaces },
+	),
+	filtersColumn(stringColumn("order_by",
+		func(f *filters) *string { return &f.OrderBy },
+	)),
+	filtersColumn(stringColumn("job_type",
+		func(f *filters) *string { return &f.JobType },
+	)),
+	filtersColumn(stringColumn("user_id_ranked_score",
+		func(f *filters) *string { return &f.UserIDRankedScore },
+	)),
+	filtersColumn(boolColumn("show_filters",
+		func(f *filters) *bool { return &f.ShowFilters },
+	)),
+}
+
+// DefaultCollectionColumns are the names of the columns used by
+// NewCollectionCSVWriter when no columns are given.
+var DefaultCollectionColumns = []string{
+	"id", "title", "description", "created", "creator_username", "num_jobs",
+	"public", "hidden",
+}
@@ -0,0 +1,186 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+// CSVWriter writes values as CSV rows with a header row.
+type CSVWriter[T any] struct {
+	w             *csv.Writer
+	columns       []*Column[T]
+	headerWritten bool
+}
+
+// NewCSVWriter returns a CSVWriter writing the given columns to w.
+func NewCSVWriter[T any](w io.Writer, columns []*Column[T]) *CSVWriter[T] {
+	return &CSVWriter[T]{w: csv.NewWriter(w), columns: columns}
+}
+
+// NewJobCSVWriter returns a CSVWriter for jobs with the named columns from
+// JobColumns, or DefaultJobColumns if none are given.
+func NewJobCSVWriter(
+	w io.Writer,
+	columns ...string,
+) (*CSVWriter[midjourney.Job], error) {
+	if len(columns) == 0 {
+		columns = DefaultJobColumns
+	}
+
+	cols, err := SelectColumns(JobColumns, columns...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCSVWriter(w, cols), nil
+}
+
+// NewCollectionCSVWriter returns a CSVWriter for collections with the named
+// columns from CollectionColumns, or DefaultCollectionColumns if none are
+// given.
+func NewCollectionCSVWriter(
+	w io.Writer,
+	columns ...string,
+) (*CSVWriter[midjourney.Collection], error) {
+	if len(columns) == 0 {
+		columns = DefaultCollectionColumns
+	}
+
+	cols, err := SelectColumns(CollectionColumns, columns...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCSVWriter(w, cols), nil
+}
+
+// Write writes a single value. The header row is written before the first
+// value.
+func (cw *CSVWriter[T]) Write(v *T) error {
+	if !cw.headerWritten {
+		err := cw.WriteHeader()
+		if err != nil {
+			return err
+		}
+	}
+
+	row := make([]string, 0, len(cw.columns))
+	for _, c := range cw.columns {
+		row = append(row, c.Get(v))
+	}
+
+	return cw.w.Write(row)
+}
+
+// WriteHeader writes the header row, if it has not already been written.
+func (cw *CSVWriter[T]) WriteHeader() error {
+	if cw.headerWritten {
+		return nil
+	}
+	cw.headerWritten = true
+
+	return cw.w.Write(ColumnNames(cw.columns))
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (cw *CSVWriter[T]) Flush() error {
+	cw.w.Flush()
+
+	return cw.w.Error()
+}
+
+// CSVReader reads values from CSV with a header row. Unknown and computed
+// columns are ignored.
+type CSVReader[T any] struct {
+	r       *csv.Reader
+	all     []*Column[T]
+	columns []*Column[T]
+	line    int
+}
+
+// NewCSVReader returns a CSVReader reading from r, mapping header names to
+// the given columns.
+func NewCSVReader[T any](r io.Reader, columns []*Column[T]) *CSVReader[T] {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+
+	return &CSVReader[T]{r: cr, all: columns}
+}
+
+// NewJobCSVReader returns a CSVReader for jobs.
+func NewJobCSVReader(r io.Reader) *CSVReader[midjourney.Job] {
+	return NewCSVReader(r, JobColumns)
+}
+
+// NewCollectionCSVReader returns a CSVReader for collections.
+func NewCollectionCSVReader(r io.Reader) *CSVReader[midjourney.Collection] {
+	return NewCSVReader(r, CollectionColumns)
+}
+
+// Read reads the next value. It returns io.EOF when there are no more values.
+func (cr *CSVReader[T]) Read() (*T, error) {
+	if cr.columns == nil {
+		err := cr.readHeader()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rec, err := cr.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	cr.line++
+
+	v := new(T)
+	for i, c := range cr.columns {
+		if c == nil || c.Set == nil || i >= len(rec) {
+			continue
+		}
+
+		err = c.Set(v, rec[i])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", cr.line, err)
+		}
+	}
+
+	return v, nil
+}
+
+func (cr *CSVReader[T]) readHeader() error {
+	header, err := cr.r.Read()
+	if err != nil {
+		return err
+	}
+	cr.line++
+
+	byName := make(map[string]*Column[T], len(cr.all))
+	for _, c := range cr.all {
+		byName[c.Name] = c
+	}
+
+	cr.columns = make([]*Column[T], len(header))
+	for i, name := range header {
+		cr.columns[i] = byName[name]
+	}
+
+	return nil
+}
+
+// ReadAll reads all remaining values.
+func (cr *CSVReader[T]) ReadAll() ([]*T, error) {
+	var vs []*T
+	for {
+		v, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return vs, nil
+		} else if err != nil {
+			return vs, err
+		}
+		vs = append(vs, v)
+	}
+}
@@ -0,0 +1,249 @@
+// Package export provides streaming CSV and JSON Lines exporters and importers
+// for MidJourney jobs and collections.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+var (
+	Err              = errors.New("export")
+	ErrUnknownColumn = fmt.Errorf("%w: unknown column", Err)
+	ErrSchema        = fmt.Errorf("%w: unsupported schema", Err)
+	ErrInvalidValue  = fmt.Errorf("%w: invalid value", Err)
+)
+
+// ListSeparator separates values of list fields within a single CSV cell.
+const ListSeparator = "|"
+
+// Column describes how a single CSV column is read from and written to a
+// value of type T. Set is nil for computed columns, which are ignored when
+// importing.
+type Column[T any] struct {
+	Name string
+	Get  func(v *T) string
+	Set  func(v *T, s string) error
+}
+
+// SelectColumns returns the columns with the given names, in the given order.
+func SelectColumns[T any](
+	all []*Column[T],
+	names ...string,
+) ([]*Column[T], error) {
+	byName := make(map[string]*Column[T], len(all))
+	for _, c := range all {
+		byName[c.Name] = c
+	}
+
+	cols := make([]*Column[T], 0, len(names))
+	for _, name := range names {
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, name)
+		}
+		cols = append(cols, c)
+	}
+
+	return cols, nil
+}
+
+// ColumnNames returns the names of the given columns.
+func ColumnNames[T any](cols []*Column[T]) []string {
+	names := make([]string, 0, len(cols))
+	for _, c := range cols {
+		names = append(names, c.Name)
+	}
+
+	return names
+}
+
+func stringColumn[T any, S ~string](
+	name string,
+	field func(*T) *S,
+) *Column[T] {
+	return &Column[T]{
+		Name: name,
+		Get:  func(v *T) string { return string(*field(v)) },
+		Set: func(v *T, s string) error {
+			*field(v) = S(s)
+
+			return nil
+		},
+	}
+}
+
+func boolColumn[T any](name string, field func(*T) *bool) *Column[T] {
+	return &Column[T]{
+		Name: name,
+		Get:  func(v *T) string { return formatBool(*field(v)) },
+		Set: func(v *T, s string) error {
+			b, err := parseBool(name, s)
+			*field(v) = b
+
+			return err
+		},
+	}
+}
+
+func intColumn[T any, N ~int](name string, field func(*T) *N) *Column[T] {
+	return &Column[T]{
+		Name: name,
+		Get:  func(v *T) string { return formatInt(int(*field(v))) },
+		Set: func(v *T, s string) error {
+			n, err := parseInt(name, s)
+			*field(v) = N(n)
+
+			return err
+		},
+	}
+}
+
+// flexStringColumn is like stringColumn, for fields which may be null. Null is
+// exported as an empty string, and empty strings are imported as null.
+func flexStringColumn[T any](
+	name string,
+	field func(*T) **midjourney.FlexString,
+) *Column[T] {
+	return &Column[T]{
+		Name: name,
+		Get:  func(v *T) string { return (*field(v)).String() },
+		Set: func(v *T, s string) error {
+			*field(v) = nil
+			if s != "" {
+				*field(v) = midjourney.NewFlexString(s)
+			}
+
+			return nil
+		},
+	}
+}
+
+// flexIntColumn is like intColumn, for fields which may be null. Null is
+// exported as an empty string, and empty strings are imported as null, while
+// zero is exported as "0".
+func flexIntColumn[T any](
+	name string,
+	field func(*T) **midjourney.FlexInt,
+) *Column[T] {
+	return &Column[T]{
+		Name: name,
+		Get: func(v *T) string {
+			if *field(v) == nil {
+				return ""
+			}
+
+			return strconv.Itoa((*field(v)).Int())
+		},
+		Set: func(v *T, s string) error {
+			*field(v) = nil
+			if s == "" {
+				return nil
+			}
+
+			n, err := parseInt(name, s)
+			if err != nil {
+				return err
+			}
+			*field(v) = midjourney.NewFlexInt(n)
+
+			return nil
+		},
+	}
+}
+
+func timeColumn[T any](
+	name string,
+	field func(*T) *midjourney.Time,
+) *Column[T] {
+	return &Column[T]{
+		Name: name,
+		Get:  func(v *T) string { return formatTime(field(v)) },
+		Set: func(v *T, s string) error {
+			return parseTime(name, s, field(v))
+		},
+	}
+}
+
+func listColumn[T any](name string, field func(*T) *[]string) *Column[T] {
+	return &Column[T]{
+		Name: name,
+		Get: func(v *T) string {
+			return strings.Join(*field(v), ListSeparator)
+		},
+		Set: func(v *T, s string) error {
+			if s != "" {
+				*field(v) = strings.Split(s, ListSeparator)
+			}
+
+			return nil
+		},
+	}
+}
+
+func computedColumn[T any](name string, get func(*T) string) *Column[T] {
+	return &Column[T]{Name: name, Get: get}
+}
+
+func formatBool(b bool) string {
+	if !b {
+		return ""
+	}
+
+	return "true"
+}
+
+func parseBool(name, s string) (bool, error) {
+	if s == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s: %q", ErrInvalidValue, name, s)
+	}
+
+	return b, nil
+}
+
+func formatTime(t *midjourney.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(midjourney.TimeFormat)
+}
+
+func parseTime(name, s string, t *midjourney.Time) error {
+	err := t.UnmarshalJSON([]byte(s))
+	if err != nil {
+		return fmt.Errorf("%w: %s: %q", ErrInvalidValue, name, s)
+	}
+
+	return nil
+}
+
+func formatInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(n)
+}
+
+func parseInt(name, s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %q", ErrInvalidValue, name, s)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,237 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testJob() *midjourney.Job {
+	return &midjourney.Job{
+		ID:            "a3052616-372b-42a1-a72b-eb86fa0be633",
+		Type:          midjourney.JobTypeGrid,
+		JobType:       "v4_grid",
+		CurrentStatus: midjourney.JobStatusCompleted,
+		EnqueueTime: midjourney.Time{
+			Time: time.Date(2022, 9, 7, 6, 58, 2, 200753000, time.UTC),
+		},
+		Prompt:            "earth, landscape, \"picturesque\"\nphoto",
+		FullCommand:       "earth, landscape --ar 16:10",
+		UserID:            "146914681683050496",
+		Username:          "jimeh",
+		Platform:          "discord",
+		PlatformChannelID: "991150132894638170",
+		PlatformMessageID: "1016966680586506291",
+		GuildID:           "662267976984297473",
+		IsPublished:       true,
+		LowPriority:       true,
+		RankingByUser:     midjourney.NewFlexInt(4),
+		ImagePaths: []string{
+			"https://storage.googleapis.com/a/0_0.png",
+			"https://storage.googleapis.com/a/0_1.png",
+		},
+		Event: &midjourney.Event{
+			Width:      midjourney.NewFlexInt(768),
+			Height:     midjourney.NewFlexInt(512),
+			BatchSize:  midjourney.NewFlexInt(4),
+			TextPrompt: []string{"earth", "landscape"},
+		},
+		ParsedParams: &midjourney.ParsedJobParams{
+			Aspect:  "16:10",
+			Testp:   true,
+			Stylize: 2500,
+			No:      []string{"people", "cars"},
+			Version: "4",
+		},
+	}
+}
+
+func testCollection() *midjourney.Collection {
+	return &midjourney.Collection{
+		ID:    "col-1",
+		Title: "Landscapes, mostly",
+		Created: &midjourney.Time{
+			Time: time.Date(2022, 12, 11, 10, 0, 0, 123000000, time.UTC),
+		},
+		CreatorUsername: "jimeh",
+		NumJobs:         midjourney.NewFlexInt(12),
+		Public:          true,
+		SearchTerms:     []string{"earth", "sky"},
+		Data: &midjourney.CollectionData{
+			Filters: &midjourney.CollectionFilters{
+				OrderBy:     "new",
+				ShowFilters: true,
+			},
+		},
+	}
+}
+
+func TestJobCSV_RoundTrip(t *testing.T) {
+	job := testJob()
+
+	var buf bytes.Buffer
+	w, err := NewJobCSVWriter(&buf, ColumnNames(JobColumns)...)
+	require.NoError(t, err)
+
+	err = w.Write(job)
+	require.NoError(t, err)
+	err = w.Write(&midjourney.Job{ID: "empty"})
+	require.NoError(t, err)
+	zero := &midjourney.Job{ID: "zero", RankingByUser: midjourney.NewFlexInt(0)}
+	err = w.Write(zero)
+	require.NoError(t, err)
+	err = w.Flush()
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), job.MainImageURL())
+	assert.Contains(t, buf.String(), job.DiscordURL())
+	assert.Contains(t, buf.String(), "people|cars")
+
+	got, err := NewJobCSVReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, job, got[0])
+	assert.Equal(t, &midjourney.Job{ID: "empty"}, got[1])
+	assert.Equal(t, zero, got[2])
+}
+
+func TestNewJobCSVWriter_Columns(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewJobCSVWriter(&buf, "id", "params_version", "video_url")
+	require.NoError(t, err)
+
+	err = w.Write(testJob())
+	require.NoError(t, err)
+	err = w.Flush()
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"id,params_version,video_url\n"+
+			"a3052616-372b-42a1-a72b-eb86fa0be633,4,"+
+			"https://i.mj.run/a3052616-372b-42a1-a72b-eb86fa0be633/video.mp4\n",
+		buf.String(),
+	)
+}
+
+func TestNewJobCSVWriter_Defaults(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewJobCSVWriter(&buf)
+	require.NoError(t, err)
+
+	err = w.WriteHeader()
+	require.NoError(t, err)
+	err = w.Flush()
+	require.NoError(t, err)
+
+	assert.Equal(t, strings.Join(DefaultJobColumns, ",")+"\n", buf.String())
+}
+
+func TestNewJobCSVWriter_UnknownColumn(t *testing.T) {
+	_, err := NewJobCSVWriter(&bytes.Buffer{}, "id", "nope")
+
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+}
+
+func TestJobCSVReader_InvalidValue(t *testing.T) {
+	r := NewJobCSVReader(strings.NewReader(
+		"id,event_width,unknown\n1,wide,foo\n",
+	))
+
+	_, err := r.Read()
+
+	assert.ErrorIs(t, err, ErrInvalidValue)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestCollectionCSV_RoundTrip(t *testing.T) {
+	col := testCollection()
+
+	var buf bytes.Buffer
+	w, err := NewCollectionCSVWriter(
+		&buf, ColumnNames(CollectionColumns)...,
+	)
+	require.NoError(t, err)
+
+	err = w.Write(col)
+	require.NoError(t, err)
+	err = w.Flush()
+	require.NoError(t, err)
+
+	got, err := NewCollectionCSVReader(&buf).ReadAll()
+	require.NoError(t, err)
+
+	assert.Equal(t, []*midjourney.Collection{col}, got)
+}
+
+func TestJobJSONL_RoundTrip(t *testing.T) {
+	jobs := []*midjourney.Job{testJob(), {ID: "other"}}
+
+	var buf bytes.Buffer
+	w := NewJobJSONLWriter(&buf)
+	for _, j := range jobs {
+		err := w.Write(j)
+		require.NoError(t, err)
+	}
+	err := w.Flush()
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(
+		lines[1], `{"schema":"midjourney.job","version":1,"data":{`,
+	))
+
+	got, err := NewJobJSONLReader(&buf).ReadAll()
+	require.NoError(t, err)
+
+	assert.Equal(t, jobs, got)
+}
+
+func TestCollectionJSONL_RoundTrip(t *testing.T) {
+	col := testCollection()
+
+	var buf bytes.Buffer
+	w := NewCollectionJSONLWriter(&buf)
+	err := w.Write(col)
+	require.NoError(t, err)
+	err = w.Flush()
+	require.NoError(t, err)
+
+	got, err := NewCollectionJSONLReader(&buf).ReadAll()
+	require.NoError(t, err)
+
+	assert.Equal(t, []*midjourney.Collection{col}, got)
+}
+
+func TestJSONLReader_Schema(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{
+			name: "other schema",
+			line: `{"schema":"midjourney.collection","version":1,"data":{}}`,
+		},
+		{
+			name: "newer version",
+			line: `{"schema":"midjourney.job","version":99,"data":{}}`,
+		},
+		{
+			name: "missing schema",
+			line: `{"id":"foo"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewJobJSONLReader(strings.NewReader(tt.line)).Read()
+
+			assert.ErrorIs(t, err, ErrSchema)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package export
+
+import (
+	"github.com/jimeh/go-midjourney"
+)
+
+type jobColumn = Column[midjourney.Job]
+
+func event(j *midjourney.Job) *midjourney.Event {
+	if j.Event == nil {
+		j.Event = &midjourney.Event{}
+	}
+
+	return j.Event
+}
+
+func params(j *midjourney.Job) *midjourney.ParsedJobParams {
+	if j.ParsedParams == nil {
+		j.ParsedParams = &midjourney.ParsedJobParams{}
+	}
+
+	return j.ParsedParams
+}
+
+// eventColumn wraps a column of the job's Event, which is only created when
+// importing a non-empty value.
+func eventColumn(c *Column[midjourney.Event]) *jobColumn {
+	return &jobColumn{
+		Name: "event_" + c.Name,
+		Get: func(j *midjourney.Job) string {
+			if j.Event == nil {
+				return ""
+			}
+
+			return c.Get(j.Event)
+		},
+		Set: func(j *midjourney.Job, s string) error {
+			if s == "" {
+				return nil
+			}
+
+			return c.Set(event(j), s)
+		},
+	}
+}
+
+// paramsColumn wraps a column of the job's ParsedParams, which is only created
+// when importing a non-empty value.
+func paramsColumn(c *Column[midjourney.ParsedJobParams]) *jobColumn {
+	return &jobColumn{
+		Name: "params_" + c.Name,
+		Get: func(j *midjourney.Job) string {
+			if j.ParsedParams == nil {
+				return ""
+			}
+
+			return c.Get(j.ParsedParams)
+		},
+		Set: func(j *midjourney.Job, s string) error {
+			if s == "" {
+				return nil
+			}
+
+			return c.Set(params(j), s)
+		},
+	}
+}
+
+type (
+	evt       = midjourney.Event
+	flexInt   = midjourney.FlexInt
+	prm       = midjourney.ParsedJobParams
+	paramsCol = Column[prm]
+)
+
+// JobColumns are all available CSV columns for jobs. Columns ending in "_url"
+// and "image_filename" are computed, and ignored when importing.
+var JobColumns = []*jobColumn{
+	stringColumn("id", func(j *midjourney.Job) *string { return &j.ID }),
+	{
+		Name: "type",
+		Get:  func(j *midjourney.Job) string { return string(j.Type) },
+		Set: func(j *midjourney.Job, s string) error {
+			j.Type = midjourney.JobType(s)
+
+			return nil
+		},
+	},
+	stringColumn("job_type",
+		func(j *midjourney.Job) *string { return &j.JobType },
+	),
+	stringColumn("service",
+		func(j *midjourney.Job) *string { return &j.Service },
+	),
+	{
+		Name: "current_status",
+		Get: func(j *midjourney.Job) string {
+			return string(j.CurrentStatus)
+		},
+		Set: func(j *midjourney.Job, s string) error {
+			j.CurrentStatus = midjourney.JobStatus(s)
+
+			return nil
+		},
+	},
+	timeColumn("enqueue_time",
+		func(j *midjourney.Job) *midjourney.Time { return &j.EnqueueTime },
+	),
+	stringColumn("prompt",
+		func(j *midjourney.Job) *string { return &j.Prompt },
+	),
+	stringColumn("full_command",
+		func(j *midjourney.Job) *string { return &j.FullCommand },
+	),
+	stringColumn("user_id",
+		func(j *midjourney.Job) *string { return &j.UserID },
+	),
+	stringColumn("username",
+		func(j *midjourney.Job) *string { return &j.Username },
+	),
+	stringColumn("platform",
+		func(j *midjourney.Job) *string { return &j.Platform },
+	),
+	stringColumn("platform_channel",
+		func(j *midjourney.Job) *string { return &j.PlatformChannel },
+	),
+	stringColumn("platform_channel_id",
+		func(j *midjourney.Job) *string { return &j.PlatformChannelID },
+	),
+	stringColumn("platform_message_id",
+		func(j *midjourney.Job) *string { return &j.PlatformMessageID },
+	),
+	stringColumn("platform_thread_id",
+		func(j *midjourney.Job) *string { return &j.PlatformThreadID },
+	),
+	stringColumn("guild_id",
+		func(j *midjourney.Job) *string { return &j.GuildID },
+	),
+	stringColumn("grid_id",
+		func(j *midjourney.Job) *string { return &j.GridID },
+	),
+	flexStringColumn("grid_num",
+		func(j *midjourney.Job) **midjourney.FlexString {
+			return &j.GridNum
+		},
+	),
+	stringColumn("reference_job_id",
+		func(j *midjourney.Job) *string { return &j.ReferenceJobID },
+	),
+	flexStringColumn("reference_image_num",
+		func(j *midjourney.Job) **midjourney.FlexString {
+			return &j.ReferenceImageNum
+		},
+	),
+	boolColumn("flagged",
+		func(j *midjourney.Job) *bool { return &j.Flagged },
+	),
+	boolColumn("followed_by_user",
+		func(j *midjourney.Job) *bool { return &j.FollowedByUser },
+	),
+	boolColumn("hidden",
+		func(j *midjourney.Job) *bool { return &j.Hidden },
+	),
+	boolColumn("is_published",
+		func(j *midjourney.Job) *bool { return &j.IsPublished },
+	),
+	boolColumn("liked_by_user",
+		func(j *midjourney.Job) *bool { return &j.LikedByUser },
+	),
+	boolColumn("low_priority",
+		func(j *midjourney.Job) *bool { return &j.LowPriority },
+	),
+	boolColumn("metered",
+		func(j *midjourney.Job) *bool { return &j.Metered },
+	),
+	boolColumn("mod_hidden",
+		func(j *midjourney.Job) *bool { return &j.ModHidden },
+	),
+	boolColumn("ranked_by_user",
+		func(j *midjourney.Job) *bool { return &j.RankedByUser },
+	),
+	flexIntColumn("ranking_by_user",
+		func(j *midjourney.Job) **midjourney.FlexInt {
+			return &j.RankingByUser
+		},
+	),
+	listColumn("image_paths",
+		func(j *midjourney.Job) *[]string { return &j.ImagePaths },
+	),
+
+	eventColumn(flexIntColumn("width",
+		func(e *evt) **flexInt { return &e.Width },
+	)),
+	eventColumn(flexIntColumn("height",
+		func(e *evt) **flexInt { return &e.Height },
+	)),
+	eventColumn(flexIntColumn("batch_size",
+		func(e *evt) **flexInt { return &e.BatchSize },
+	)),
+	eventColumn(listColumn("text_prompt",
+		func(e *evt) *[]string { return &e.TextPrompt },
+	)),
+	eventColumn(listColumn("image_prompts",
+		func(e *evt) *[]string { return &e.ImagePrompts },
+	)),
+	eventColumn(stringColumn("seed_image_url",
+		func(e *evt) *string { return &e.SeedImageURL },
+	)),
+
+	paramsColumn(boolColumn("anime", func(p *prm) *bool { return &p.Anime })),
+	paramsColumn(stringColumn("aspect",
+		func(p *prm) *string { return &p.Aspect },
+	)),
+	paramsColumn(boolColumn("creative",
+		func(p *prm) *bool { return &p.Creative },
+	)),
+	paramsColumn(boolColumn("fast", func(p *prm) *bool { return &p.Fast })),
+	paramsColumn(boolColumn("hd", func(p *prm) *bool { return &p.HD })),
+	paramsColumn(listColumn("no", func(p *prm) *[]string { return &p.No })),
+	paramsColumn(stringColumn("style",
+		func(p *prm) *string { return &p.Style },
+	)),
+	paramsColumn(intColumn("stylize",
+		func(p *prm) *int { return &p.Stylize },
+	)),
+	paramsColumn(boolColumn("test", func(p *prm) *bool { return &p.Test })),
+	paramsColumn(boolColumn("testp", func(p *prm) *bool { return &p.Testp })),
+	paramsColumn(boolColumn("tile", func(p *prm) *bool { return &p.Tile })),
+	paramsColumn(boolColumn("upanime",
+		func(p *prm) *bool { return &p.Upanime },
+	)),
+	paramsColumn(boolColumn("upbeta",
+		func(p *prm) *bool { return &p.Upbeta },
+	)),
+	paramsColumn(boolColumn("uplight",
+		func(p *prm) *bool { return &p.Uplight },
+	)),
+	paramsColumn(&paramsCol{
+		Name: "version",
+		Get:  func(p *prm) string { return string(p.Version) },
+		Set: func(p *prm, s string) error {
+			p.Version = midjourney.AlgorithmVersion(s)
+
+			return nil
+		},
+	}),
+	paramsColumn(boolColumn("vibe", func(p *prm) *bool { return &p.Vibe })),
+	paramsColumn(boolColumn("video", func(p *prm) *bool { return &p.Video })),
+
+	computedColumn("main_image_url", (*midjourney.Job).MainImageURL),
+	computedColumn("thumbnail_url", func(j *midjourney.Job) string {
+		return j.ThumbnailURL(midjourney.ThumbnailSizeMedium)
+	}),
+	computedColumn("video_url", (*midjourney.Job).VideoURL),
+	computedColumn("discord_url", (*midjourney.Job).DiscordURL),
+	computedColumn("image_filename", (*midjourney.Job).ImageFilename),
+}
+
+// DefaultJobColumns are the names of the columns used by NewJobCSVWriter when
+// no columns are given.
+var DefaultJobColumns = []string{
+	"id", "type", "enqueue_time", "username", "prompt", "full_command",
+	"params_version", "params_aspect", "event_width", "event_height",
+	"reference_job_id", "reference_image_num", "main_image_url",
+	"discord_url",
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+// Schema names and the current schema version of JSON Lines records.
+const (
+	SchemaJob        = "midjourney.job"
+	SchemaCollection = "midjourney.collection"
+	SchemaVersion    = 1
+)
+
+// Record is a single JSON Lines record, wrapping a value with the name and
+// version of its schema.
+type Record[T any] struct {
+	Schema  string `json:"schema"`
+	Version int    `json:"version"`
+	Data    *T     `json:"data"`
+}
+
+// JSONLWriter writes values as JSON Lines records.
+type JSONLWriter[T any] struct {
+	w      *bufio.Writer
+	enc    *json.Encoder
+	schema string
+}
+
+// NewJSONLWriter returns a JSONLWriter writing records of the given schema to
+// w.
+func NewJSONLWriter[T any](w io.Writer, schema string) *JSONLWriter[T] {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	enc.SetEscapeHTML(false)
+
+	return &JSONLWriter[T]{w: bw, enc: enc, schema: schema}
+}
+
+// NewJobJSONLWriter returns a JSONLWriter for jobs.
+func NewJobJSONLWriter(w io.Writer) *JSONLWriter[midjourney.Job] {
+	return NewJSONLWriter[midjourney.Job](w, SchemaJob)
+}
+
+// NewCollectionJSONLWriter returns a JSONLWriter for collections.
+func NewCollectionJSONLWriter(
+	w io.Writer,
+) *JSONLWriter[midjourney.Collection] {
+	return NewJSONLWriter[midjourney.Collection](w, SchemaCollection)
+}
+
+// Write writes a single value as a record.
+func (jw *JSONLWriter[T]) Write(v *T) error {
+	return jw.enc.Encode(&Record[T]{
+		Schema:  jw.schema,
+		Version: SchemaVersion,
+		Data:    v,
+	})
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (jw *JSONLWriter[T]) Flush() error {
+	return jw.w.Flush()
+}
+
+// JSONLReader reads values from JSON Lines records.
+type JSONLReader[T any] struct {
+	dec    *json.Decoder
+	schema string
+	line   int
+}
+
+// NewJSONLReader returns a JSONLReader reading records of the given schema
+// from r.
+func NewJSONLReader[T any](r io.Reader, schema string) *JSONLReader[T] {
+	return &JSONLReader[T]{dec: json.NewDecoder(r), schema: schema}
+}
+
+// NewJobJSONLReader returns a JSONLReader for jobs.
+func NewJobJSONLReader(r io.Reader) *JSONLReader[midjourney.Job] {
+	return NewJSONLReader[midjourney.Job](r, SchemaJob)
+}
+
+// NewCollectionJSONLReader returns a JSONLReader for collections.
+func NewCollectionJSONLReader(
+	r io.Reader,
+) *JSONLReader[midjourney.Collection] {
+	return NewJSONLReader[midjourney.Collection](r, SchemaCollection)
+}
+
+// Read reads the next value. It returns io.EOF when there are no more records,
+// and an error wrapping ErrSchema for records of another schema or a newer
+// version.
+func (jr *JSONLReader[T]) Read() (*T, error) {
+	rec := &Record[T]{}
+	err := jr.dec.Decode(rec)
+	if err != nil {
+		return nil, err
+	}
+	jr.line++
+
+	if rec.Schema != jr.schema || rec.Version < 1 ||
+		rec.Version > SchemaVersion {
+		return nil, fmt.Errorf(
+			"%w: record %d: %s version %d",
+			ErrSchema, jr.line, rec.Schema, rec.Version,
+		)
+	}
+	if rec.Data == nil {
+		rec.Data = new(T)
+	}
+
+	return rec.Data, nil
+}
+
+// ReadAll reads all remaining values.
+func (jr *JSONLReader[T]) ReadAll() ([]*T, error) {
+	var vs []*T
+	for {
+		v, err := jr.Read()
+		if errors.Is(err, io.EOF) {
+			return vs, nil
+		} else if err != nil {
+			return vs, err
+		}
+		vs = append(vs, v)
+	}
+}
@@ -0,0 +1,217 @@
+package midjourney
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var ErrUnknownFields = fmt.Errorf("%w: unknown fields in response", Err)
+
+// StrictMode controls how APIClient handles fields in API responses which are
+// not modeled by this package.
+type StrictMode int
+
+const (
+	// StrictModeOff silently retains unknown fields in Extra maps.
+	StrictModeOff StrictMode = iota
+
+	// StrictModeLog logs a warning when unknown fields are encountered.
+	StrictModeLog
+
+	// StrictModeError returns an error wrapping ErrUnknownFields when unknown
+	// fields are encountered. APIClient still fully decodes the result it is
+	// given, but Client methods treat the error like any other, and return it
+	// without a result.
+	StrictModeError
+)
+
+// extraHolder is implemented by types which retain unknown JSON fields.
+type extraHolder interface {
+	extraFields() map[string]json.RawMessage
+}
+
+var knownFieldsCache sync.Map
+
+// knownFields returns the JSON field names of the given struct type.
+func knownFields(t reflect.Type) map[string]bool {
+	if v, ok := knownFieldsCache.Load(t); ok {
+		return v.(map[string]bool)
+	}
+
+	fields := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = true
+	}
+	knownFieldsCache.Store(t, fields)
+
+	return fields
+}
+
+// unmarshalWithExtra unmarshals b into v, a pointer to a struct type without
+// custom JSON methods, and returns all fields of b not known to v.
+func unmarshalWithExtra(
+	b []byte,
+	v any,
+) (map[string]json.RawMessage, error) {
+	err := json.Unmarshal(b, v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Values like null are valid for any type, but have no fields.
+	if b = bytes.TrimSpace(b); len(b) == 0 || b[0] != '{' {
+		return nil, nil
+	}
+
+	raw := map[string]json.RawMessage{}
+	err = json.Unmarshal(b, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	known := knownFields(reflect.TypeOf(v).Elem())
+
+	var extra map[string]json.RawMessage
+	for k, val := range raw {
+		if known[k] {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[k] = val
+	}
+
+	return extra, nil
+}
+
+// marshalWithExtra marshals v, and appends the given extra fields to the
+// resulting JSON object, in sorted order. Extra fields are never allowed to
+// override modeled fields.
+func marshalWithExtra(
+	v any,
+	extra map[string]json.RawMessage,
+) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	known := knownFields(reflect.TypeOf(v).Elem())
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		if !known[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(b[:len(b)-1])
+	for _, k := range keys {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		val := extra[k]
+		if len(val) == 0 {
+			val = json.RawMessage("null")
+		}
+		err = json.Compact(&buf, val)
+		if err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnknownFields returns the paths of all unknown fields retained in Extra maps
+// anywhere within v, sorted and deduplicated. Paths are formatted as the type
+// name followed by the field name, for example "Job.new_field".
+func UnknownFields(v any) []string {
+	w := &extraWalker{
+		seen:    map[string]bool{},
+		visited: map[uintptr]bool{},
+	}
+	w.walk(reflect.ValueOf(v))
+
+	fields := make([]string, 0, len(w.seen))
+	for f := range w.seen {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+
+	return fields
+}
+
+var extraHolderType = reflect.TypeOf((*extraHolder)(nil)).Elem()
+
+type extraWalker struct {
+	seen    map[string]bool
+	visited map[uintptr]bool
+}
+
+func (w *extraWalker) walk(v reflect.Value) {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Interface:
+		if !v.IsNil() {
+			w.walk(v.Elem())
+		}
+	case reflect.Pointer:
+		if v.IsNil() || w.visited[v.Pointer()] {
+			return
+		}
+		w.visited[v.Pointer()] = true
+		w.walk(v.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			w.walk(v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			w.walk(iter.Value())
+		}
+	case reflect.Struct:
+		if v.CanAddr() && v.Addr().Type().Implements(extraHolderType) {
+			h, _ := v.Addr().Interface().(extraHolder)
+			for k := range h.extraFields() {
+				w.seen[v.Type().Name()+"."+k] = true
+			}
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				w.walk(v.Field(i))
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package midjourney
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jobWithExtraJSON = `{
+	"id": "job-1",
+	"enqueue_time": null,
+	"prompt": "a cat",
+	"new_field": {"a": [1, 2]},
+	"event": {"height": 512, "eventType": "imagine"},
+	"zeta": true
+}`
+
+func TestJob_Extra(t *testing.T) {
+	var j Job
+	err := json.Unmarshal([]byte(jobWithExtraJSON), &j)
+	require.NoError(t, err)
+
+	assert.Equal(t, "job-1", j.ID)
+	assert.Equal(t, map[string]json.RawMessage{
+		"new_field": json.RawMessage(`{"a": [1, 2]}`),
+		"zeta":      json.RawMessage(`true`),
+	}, j.Extra)
+	require.NotNil(t, j.Event)
+	assert.Equal(t, NewFlexInt(512), j.Event.Height)
+	assert.Equal(t, map[string]json.RawMessage{
+		"eventType": json.RawMessage(`"imagine"`),
+	}, j.Event.Extra)
+
+	b, err := json.Marshal(j)
+	require.NoError(t, err)
+	assert.JSONEq(t, jobWithExtraJSON, string(b))
+
+	var got Job
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+	assert.Len(t, got.Extra, 2)
+	assert.JSONEq(t, `{"a":[1,2]}`, string(got.Extra["new_field"]))
+	assert.Equal(t, j.Event.Extra, got.Event.Extra)
+}
+
+func TestJob_ExtraNilWithoutUnknownFields(t *testing.T) {
+	var j Job
+	err := json.Unmarshal([]byte(`{"id":"job-1","event":{"width":1}}`), &j)
+	require.NoError(t, err)
+
+	assert.Nil(t, j.Extra)
+	assert.Nil(t, j.Event.Extra)
+}
+
+func TestJob_MarshalJSONExtra(t *testing.T) {
+	tests := []struct {
+		name string
+		job  *Job
+		want string
+	}{
+		{
+			name: "empty",
+			job:  &Job{Extra: map[string]json.RawMessage{"b": []byte("1")}},
+			want: `{"enqueue_time":null,"b":1}`,
+		},
+		{
+			name: "sorted",
+			job: &Job{
+				ID: "x",
+				Extra: map[string]json.RawMessage{
+					"b": []byte(`"2"`),
+					"a": []byte(`[ 1 ]`),
+				},
+			},
+			want: `{"enqueue_time":null,"id":"x","a":[1],"b":"2"}`,
+		},
+		{
+			name: "modeled fields take precedence",
+			job: &Job{
+				ID:    "x",
+				Extra: map[string]json.RawMessage{"id": []byte(`"y"`)},
+			},
+			want: `{"enqueue_time":null,"id":"x"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.job)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, string(b))
+		})
+	}
+}
+
+func TestCollection_Extra(t *testing.T) {
+	in := `{"id":"col-1","title":"Foo","sort_order":3}`
+
+	var col Collection
+	err := json.Unmarshal([]byte(in), &col)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Foo", col.Title)
+	assert.Equal(t,
+		map[string]json.RawMessage{"sort_order": json.RawMessage("3")},
+		col.Extra,
+	)
+
+	b, err := json.Marshal(&col)
+	require.NoError(t, err)
+	assert.JSONEq(t, in, string(b))
+}
+
+func TestUnknownFields(t *testing.T) {
+	var jobs []*Job
+	err := json.Unmarshal([]byte(`[
+		{"id":"a","foo":1,"event":{"bar":2}},
+		{"id":"b","foo":3},
+		null
+	]`), &jobs)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]string{"Event.bar", "Job.foo"},
+		UnknownFields(jobs),
+	)
+	assert.Empty(t, UnknownFields(&Job{ID: "c"}))
+	assert.Empty(t, UnknownFields(nil))
+}
+
+func TestAPIClient_StrictMode(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":"a","new_field":1}]`))
+	})
+
+	tests := []struct {
+		name    string
+		mode    StrictMode
+		wantErr error
+		wantLog string
+	}{
+		{name: "off", mode: StrictModeOff},
+		{
+			name:    "log",
+			mode:    StrictModeLog,
+			wantLog: `"fields":["Job.new_field"]`,
+		},
+		{name: "error", mode: StrictModeError, wantErr: ErrUnknownFields},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			c := newTestClient(t, handler)
+			err := c.API.Set(
+				WithStrictMode(tt.mode),
+				WithLogger(zerolog.New(&logs)),
+			)
+			require.NoError(t, err)
+
+			var jobs []*Job
+			err = c.API.Get(context.Background(), "app/jobs/", nil, &jobs)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Contains(t, err.Error(), "Job.new_field")
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, jobs, 1)
+			assert.Equal(t, "a", jobs[0].ID)
+			assert.Contains(t, jobs[0].Extra, "new_field")
+
+			if tt.wantLog != "" {
+				assert.Contains(t, logs.String(), tt.wantLog)
+			} else {
+				assert.NotContains(t, logs.String(), "unknown fields")
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+type atomFeed struct {
+	XMLName xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Links   []*atomLink  `xml:"link"`
+	Entries []*atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Author  *atomAuthor  `xml:"author,omitempty"`
+	Links   []*atomLink  `xml:"link"`
+	Content *atomContent `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// WriteAtom writes the feed as an Atom document to w.
+func (f *Feed) WriteAtom(w io.Writer) error {
+	af := &atomFeed{
+		ID:      f.ID,
+		Title:   f.Title,
+		Updated: atomTime(f.Updated()),
+		Links:   []*atomLink{},
+	}
+	if f.Link != "" {
+		af.Links = append(af.Links, &atomLink{Href: f.Link, Rel: "alternate"})
+	}
+	if f.FeedURL != "" {
+		af.Links = append(af.Links, &atomLink{Href: f.FeedURL, Rel: "self"})
+	}
+
+	for _, j := range f.Jobs {
+		e := &atomEntry{
+			ID:      EntryID(j),
+			Title:   entryTitle(j),
+			Updated: atomTime(j.EnqueueTime.Time),
+			Links: []*atomLink{
+				{Href: JobURL(j), Rel: "alternate", Type: "text/html"},
+			},
+			Content: &atomContent{Type: "html", Body: entryContent(j)},
+		}
+		if a := entryAuthor(j); a != "" {
+			e.Author = &atomAuthor{Name: a}
+		}
+		for _, enc := range enclosures(j) {
+			e.Links = append(e.Links, &atomLink{
+				Href: enc.URL, Rel: "enclosure", Type: enc.Type,
+			})
+		}
+		af.Entries = append(af.Entries, e)
+	}
+
+	return writeXML(w, af)
+}
+
+func atomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func writeXML(w io.Writer, v any) error {
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+
+	return err
+}
@@ -0,0 +1,241 @@
+// Package feed renders MidJourney jobs as Atom, RSS 2.0 and JSON Feed 1.1
+// documents.
+package feed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+// JobsURL is the base URL of job pages on the MidJourney website.
+const JobsURL = "https://www.midjourney.com/app/jobs/"
+
+// RecentJobsGetter is implemented by types which can query recent jobs, like
+// midjourney.Client.
+type RecentJobsGetter interface {
+	RecentJobs(
+		ctx context.Context,
+		q *midjourney.RecentJobsQuery,
+	) (*midjourney.RecentJobs, error)
+}
+
+// Feed is a list of jobs which can be rendered in different feed formats.
+type Feed struct {
+	ID          string
+	Title       string
+	Description string
+	Link        string
+	FeedURL     string
+	Jobs        []*midjourney.Job
+}
+
+// FromRecentJobs returns a Feed for the given recent jobs result, with a title
+// describing the query.
+func FromRecentJobs(rj *midjourney.RecentJobs) *Feed {
+	return &Feed{
+		ID:    feedID(&rj.Query),
+		Title: QueryTitle(&rj.Query),
+		Link:  "https://www.midjourney.com/app/feed/",
+		Jobs:  rj.Jobs,
+	}
+}
+
+// feedID returns the ID of the feed for q. Paging and refresh parameters
+// differ between fetches of the same feed, so they are left out.
+func feedID(q *midjourney.RecentJobsQuery) string {
+	idq := *q
+	idq.FromDate = time.Time{}
+	idq.Page = 0
+	idq.RefreshAPI = 0
+
+	return "tag:midjourney.com,2022:feed?" + idq.URLValues().Encode()
+}
+
+// Fetch queries up to the given number of pages of jobs, and returns them as a
+// Feed.
+func Fetch(
+	ctx context.Context,
+	getter RecentJobsGetter,
+	q *midjourney.RecentJobsQuery,
+	pages int,
+) (*Feed, error) {
+	if pages < 1 {
+		pages = 1
+	}
+
+	rj, err := getter.RecentJobs(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	f := FromRecentJobs(rj)
+	for i := 1; i < pages && len(rj.Jobs) > 0; i++ {
+		rj, err = getter.RecentJobs(ctx, rj.Query.NextPage())
+		if err != nil {
+			return nil, err
+		}
+		f.Jobs = append(f.Jobs, rj.Jobs...)
+	}
+
+	return f, nil
+}
+
+// QueryTitle returns a human readable title for the given query.
+func QueryTitle(q *midjourney.RecentJobsQuery) string {
+	parts := []string{"MidJourney"}
+
+	switch {
+	case q.CollectionID != "":
+		parts = append(parts, "collection "+q.CollectionID)
+	case q.UserIDLiked != "":
+		parts = append(parts, "likes of user "+q.UserIDLiked)
+	case q.UserID != "":
+		parts = append(parts, "jobs of user "+q.UserID)
+	case q.Personal:
+		parts = append(parts, "personal feed")
+	default:
+		parts = append(parts, "community feed")
+	}
+
+	if q.Prompt != "" {
+		parts = append(parts, fmt.Sprintf("matching %q", q.Prompt))
+	}
+	if q.OrderBy != "" {
+		parts = append(parts, "("+string(q.OrderBy)+")")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Updated returns the most recent enqueue time of all jobs in the feed.
+func (f *Feed) Updated() time.Time {
+	var t time.Time
+	for _, j := range f.Jobs {
+		if j.EnqueueTime.After(t) {
+			t = j.EnqueueTime.Time
+		}
+	}
+
+	return t
+}
+
+// EntryID returns the stable ID of the feed entry for the given job.
+func EntryID(j *midjourney.Job) string {
+	return "tag:midjourney.com,2022:job/" + j.ID
+}
+
+// JobURL returns the URL of the job's page on the MidJourney website.
+func JobURL(j *midjourney.Job) string {
+	return JobsURL + j.ID + "/"
+}
+
+func entryTitle(j *midjourney.Job) string {
+	title := j.Prompt
+	if title == "" {
+		title = j.ID
+	}
+
+	r := []rune(title)
+	if len(r) > 100 {
+		title = string(r[:100]) + "…"
+	}
+
+	return title
+}
+
+func entryAuthor(j *midjourney.Job) string {
+	if j.Username != "" {
+		return j.Username
+	}
+
+	return j.UserID
+}
+
+func entryContent(j *midjourney.Job) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<p><img src="%s" alt=""></p>`,
+		escape(j.ThumbnailURL(midjourney.ThumbnailSizeLarge)),
+	)
+	cmd := j.FullCommand
+	if cmd == "" {
+		cmd = j.Prompt
+	}
+	fmt.Fprintf(&b, "<p>%s</p>", escape(cmd))
+
+	return b.String()
+}
+
+type enclosure struct {
+	URL  string
+	Type string
+}
+
+func enclosures(j *midjourney.Job) []enclosure {
+	encs := []enclosure{{URL: j.MainImageURL(), Type: "image/png"}}
+	if v := j.VideoURL(); v != "" {
+		encs = append(encs, enclosure{URL: v, Type: "video/mp4"})
+	}
+
+	return encs
+}
+
+var escaper = strings.NewReplacer(
+	"&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&#39;",
+)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// Format is a feed document format.
+type Format string
+
+const (
+	FormatAtom Format = "atom"
+	FormatRSS  Format = "rss"
+	FormatJSON Format = "json"
+)
+
+// Valid reports if f is a supported format.
+func (f Format) Valid() bool {
+	switch f {
+	case FormatAtom, FormatRSS, FormatJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatRSS:
+		return "application/rss+xml; charset=utf-8"
+	case FormatJSON:
+		return "application/feed+json; charset=utf-8"
+	case FormatAtom:
+		return "application/atom+xml; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Write writes the feed in the given format to w.
+func (f *Feed) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatAtom:
+		return f.WriteAtom(w)
+	case FormatRSS:
+		return f.WriteRSS(w)
+	case FormatJSON:
+		return f.WriteJSON(w)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
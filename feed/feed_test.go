@@ -0,0 +1,279 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGetter struct {
+	pages   [][]*midjourney.Job
+	queries []*midjourney.RecentJobsQuery
+}
+
+func (g *fakeGetter) RecentJobs(
+	_ context.Context,
+	q *midjourney.RecentJobsQuery,
+) (*midjourney.RecentJobs, error) {
+	g.queries = append(g.queries, q)
+
+	rj := &midjourney.RecentJobs{Query: *q, Page: q.Page}
+	if q.Page < len(g.pages) {
+		rj.Jobs = g.pages[q.Page]
+	}
+
+	return rj, nil
+}
+
+func testJobs() []*midjourney.Job {
+	return []*midjourney.Job{
+		{
+			ID:          "grid-1",
+			Type:        midjourney.JobTypeGrid,
+			Prompt:      "a <cat> & a dog",
+			FullCommand: "a <cat> & a dog --ar 3:2",
+			Username:    "jimeh",
+			EnqueueTime: midjourney.Time{
+				Time: time.Date(2022, 12, 11, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			ID:     "upscale-1",
+			Type:   midjourney.JobTypeUpscale,
+			Prompt: "a bird",
+			EnqueueTime: midjourney.Time{
+				Time: time.Date(2022, 12, 10, 10, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+}
+
+func TestFeed_WriteAtom(t *testing.T) {
+	f := &Feed{ID: "feed-id", Title: "Test", Jobs: testJobs()}
+
+	var buf bytes.Buffer
+	err := f.WriteAtom(&buf)
+	require.NoError(t, err)
+
+	var got atomFeed
+	err = xml.Unmarshal(buf.Bytes(), &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Test", got.Title)
+	assert.Equal(t, "2022-12-11T10:00:00Z", got.Updated)
+	require.Len(t, got.Entries, 2)
+
+	e := got.Entries[0]
+	assert.Equal(t, "tag:midjourney.com,2022:job/grid-1", e.ID)
+	assert.Equal(t, "a <cat> & a dog", e.Title)
+	assert.Equal(t, "2022-12-11T10:00:00Z", e.Updated)
+	assert.Equal(t, "jimeh", e.Author.Name)
+	assert.Contains(t, e.Content.Body, "a &lt;cat&gt; &amp; a dog --ar 3:2")
+	assert.Equal(t, []*atomLink{
+		{Href: JobsURL + "grid-1/", Rel: "alternate", Type: "text/html"},
+		{
+			Href: "https://mj-gallery.com/grid-1/grid_0.png",
+			Rel:  "enclosure", Type: "image/png",
+		},
+		{
+			Href: "https://i.mj.run/grid-1/video.mp4",
+			Rel:  "enclosure", Type: "video/mp4",
+		},
+	}, e.Links)
+	assert.Len(t, got.Entries[1].Links, 2)
+}
+
+func TestFeed_WriteRSS(t *testing.T) {
+	f := &Feed{
+		Title:   "Test",
+		Link:    "https://www.midjourney.com/",
+		FeedURL: "https://example.com/feed",
+		Jobs:    testJobs(),
+	}
+
+	var buf bytes.Buffer
+	err := f.WriteRSS(&buf)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(),
+		`xmlns:atom="http://www.w3.org/2005/Atom"`,
+	)
+	assert.Contains(t, buf.String(),
+		`<atom:link href="https://example.com/feed"`,
+	)
+
+	var got rssFeed
+	err = xml.Unmarshal(buf.Bytes(), &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0", got.Version)
+	require.Len(t, got.Channel.Items, 2)
+
+	item := got.Channel.Items[0]
+	assert.Equal(t, "tag:midjourney.com,2022:job/grid-1", item.GUID.Value)
+	assert.False(t, item.GUID.IsPermaLink)
+	assert.Equal(t, "Sun, 11 Dec 2022 10:00:00 +0000", item.PubDate)
+	assert.Equal(t, &rssEnclosure{
+		URL:  "https://mj-gallery.com/grid-1/grid_0.png",
+		Type: "image/png",
+	}, item.Enclosure)
+}
+
+func TestFeed_WriteJSON(t *testing.T) {
+	f := &Feed{Title: "Test", Jobs: testJobs()}
+
+	var buf bytes.Buffer
+	err := f.WriteJSON(&buf)
+	require.NoError(t, err)
+
+	var got jsonFeed
+	err = json.Unmarshal(buf.Bytes(), &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, jsonFeedVersion, got.Version)
+	require.Len(t, got.Items, 2)
+
+	item := got.Items[0]
+	assert.Equal(t, "tag:midjourney.com,2022:job/grid-1", item.ID)
+	assert.Equal(t, "2022-12-11T10:00:00Z", item.DatePublished)
+	assert.Equal(t, []*jsonFeedAuthor{{Name: "jimeh"}}, item.Authors)
+	assert.Len(t, item.Attachments, 2)
+	assert.Len(t, got.Items[1].Attachments, 1)
+}
+
+func TestFetch(t *testing.T) {
+	jobs := testJobs()
+	g := &fakeGetter{pages: [][]*midjourney.Job{jobs[:1], jobs[1:]}}
+
+	f, err := Fetch(context.Background(), g, &midjourney.RecentJobsQuery{
+		UserID: "123",
+	}, 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, "MidJourney jobs of user 123", f.Title)
+	assert.Equal(t, jobs, f.Jobs)
+	assert.Len(t, g.queries, 3)
+	assert.Equal(t, 1, g.queries[1].Page)
+}
+
+func TestFetch_StableID(t *testing.T) {
+	g := &fakeGetter{pages: [][]*midjourney.Job{testJobs()}}
+	q := &midjourney.RecentJobsQuery{OrderBy: midjourney.OrderNew}
+
+	f1, err := Fetch(context.Background(), g, q, 2)
+	require.NoError(t, err)
+	f2, err := Fetch(context.Background(), g, q, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, f1.ID, f2.ID)
+	assert.Equal(t, "tag:midjourney.com,2022:feed?orderBy=new&refreshApi=0",
+		f1.ID,
+	)
+
+	rj := &midjourney.RecentJobs{Query: *g.queries[1]}
+	rj.Query.RefreshAPI = 1
+	assert.Equal(t, f1.ID, FromRecentJobs(rj).ID)
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		wantStatus  int
+		wantType    string
+		wantContain string
+	}{
+		{
+			name:        "default atom",
+			url:         "/feed?userId=123",
+			wantStatus:  http.StatusOK,
+			wantType:    FormatAtom.ContentType(),
+			wantContain: "<feed xmlns=\"http://www.w3.org/2005/Atom\">",
+		},
+		{
+			name:        "rss",
+			url:         "/feed?format=rss",
+			wantStatus:  http.StatusOK,
+			wantType:    FormatRSS.ContentType(),
+			wantContain: "<rss version=\"2.0\"",
+		},
+		{
+			name:        "json",
+			url:         "/feed?format=json",
+			wantStatus:  http.StatusOK,
+			wantType:    FormatJSON.ContentType(),
+			wantContain: jsonFeedVersion,
+		},
+		{
+			name:       "unknown format",
+			url:        "/feed?format=html",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid pages",
+			url:        "/feed?pages=0",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid query",
+			url:        "/feed?amount=lots",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				Getter: &fakeGetter{
+					pages: [][]*midjourney.Job{testJobs()},
+				},
+			}
+			rec := httptest.NewRecorder()
+
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantType != "" {
+				assert.Equal(t, tt.wantType, rec.Header().Get("Content-Type"))
+			}
+			assert.Contains(t, rec.Body.String(), tt.wantContain)
+		})
+	}
+}
+
+func TestHandler_Defaults(t *testing.T) {
+	g := &fakeGetter{}
+	h := &Handler{
+		Getter: g,
+		Defaults: &midjourney.RecentJobsQuery{
+			Amount:  50,
+			OrderBy: midjourney.OrderNew,
+		},
+	}
+
+	h.ServeHTTP(
+		httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodGet, "/?orderBy=hot&pages=10", nil),
+	)
+
+	require.Len(t, g.queries, 1)
+	assert.Equal(t, 50, g.queries[0].Amount)
+	assert.Equal(t, midjourney.OrderHot, g.queries[0].OrderBy)
+}
+
+func TestFormat_Valid(t *testing.T) {
+	for _, f := range []Format{FormatAtom, FormatRSS, FormatJSON} {
+		assert.True(t, f.Valid(), f)
+	}
+	for _, f := range []Format{"", "xml", "ATOM"} {
+		assert.False(t, f.Valid(), f)
+	}
+}
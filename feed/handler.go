@@ -0,0 +1,119 @@
+package feed
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+// DefaultMaxPages is the maximum number of pages a Handler fetches per request
+// when Handler.MaxPages is not set.
+const DefaultMaxPages = 5
+
+var (
+	Err              = errors.New("feed")
+	ErrUnknownFormat = fmt.Errorf("%w: unknown format", Err)
+)
+
+// Handler is a http.Handler which serves a feed of jobs matching a query
+// described by URL parameters. The parameters are the same as those produced
+// by midjourney.RecentJobsQuery.URLValues, along with the optional "format"
+// ("atom", "rss" or "json") and "pages" parameters.
+type Handler struct {
+	Getter RecentJobsGetter
+
+	// MaxPages limits the value of the "pages" parameter.
+	MaxPages int
+
+	// Defaults is used for query fields not set in the URL parameters.
+	Defaults *midjourney.RecentJobsQuery
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	format := Format(params.Get("format"))
+	if format == "" {
+		format = FormatAtom
+	}
+	if !format.Valid() {
+		http.Error(w, "unknown format", http.StatusBadRequest)
+
+		return
+	}
+
+	pages := 1
+	if s := params.Get("pages"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			http.Error(w, "invalid pages", http.StatusBadRequest)
+
+			return
+		}
+		pages = n
+	}
+	maxPages := h.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+	if pages > maxPages {
+		pages = maxPages
+	}
+
+	q, err := h.query(params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	f, err := Fetch(r.Context(), h.Getter, q, pages)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+
+		return
+	}
+	f.FeedURL = requestURL(r)
+
+	var buf bytes.Buffer
+	err = f.Write(&buf, format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	_, _ = buf.WriteTo(w)
+}
+
+func (h *Handler) query(
+	params url.Values,
+) (*midjourney.RecentJobsQuery, error) {
+	if h.Defaults != nil {
+		defaults := h.Defaults.URLValues()
+		for k, v := range defaults {
+			if _, ok := params[k]; !ok {
+				params[k] = v
+			}
+		}
+	}
+
+	return midjourney.ParseRecentJobsQuery(params)
+}
+
+func requestURL(r *http.Request) string {
+	u := *r.URL
+	u.Host = r.Host
+	u.Scheme = "http"
+	if r.TLS != nil {
+		u.Scheme = "https"
+	}
+
+	return u.String()
+}
@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string          `json:"version"`
+	Title       string          `json:"title"`
+	HomePageURL string          `json:"home_page_url,omitempty"`
+	FeedURL     string          `json:"feed_url,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Items       []*jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string                `json:"id"`
+	URL           string                `json:"url,omitempty"`
+	Title         string                `json:"title,omitempty"`
+	ContentHTML   string                `json:"content_html,omitempty"`
+	Image         string                `json:"image,omitempty"`
+	DatePublished string                `json:"date_published,omitempty"`
+	Authors       []*jsonFeedAuthor     `json:"authors,omitempty"`
+	Attachments   []*jsonFeedAttachment `json:"attachments,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedAttachment struct {
+	URL      string `json:"url"`
+	MIMEType string `json:"mime_type"`
+}
+
+// WriteJSON writes the feed as a JSON Feed 1.1 document to w.
+func (f *Feed) WriteJSON(w io.Writer) error {
+	jf := &jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.FeedURL,
+		Description: f.Description,
+		Items:       []*jsonFeedItem{},
+	}
+
+	for _, j := range f.Jobs {
+		item := &jsonFeedItem{
+			ID:          EntryID(j),
+			URL:         JobURL(j),
+			Title:       entryTitle(j),
+			ContentHTML: entryContent(j),
+			Image:       j.MainImageURL(),
+		}
+		if !j.EnqueueTime.IsZero() {
+			item.DatePublished = j.EnqueueTime.UTC().Format(time.RFC3339)
+		}
+		if a := entryAuthor(j); a != "" {
+			item.Authors = []*jsonFeedAuthor{{Name: a}}
+		}
+		for _, enc := range enclosures(j) {
+			item.Attachments = append(item.Attachments, &jsonFeedAttachment{
+				URL: enc.URL, MIMEType: enc.Type,
+			})
+		}
+		jf.Items = append(jf.Items, item)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(jf)
+}
@@ -0,0 +1,90 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+type rssFeed struct {
+	XMLName xml.Name    `xml:"rss"`
+	Version string      `xml:"version,attr"`
+	AtomNS  string      `xml:"xmlns:atom,attr,omitempty"`
+	Channel *rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+	AtomLink      *atomLink  `xml:"atom:link,omitempty"`
+	Items         []*rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	Author      string        `xml:"author,omitempty"`
+	GUID        *rssGUID      `xml:"guid"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length int    `xml:"length,attr"`
+}
+
+// WriteRSS writes the feed as an RSS 2.0 document to w. As RSS only allows a
+// single enclosure per item, only the main image of each job is included.
+func (f *Feed) WriteRSS(w io.Writer) error {
+	ch := &rssChannel{
+		Title:       f.Title,
+		Link:        f.Link,
+		Description: f.Description,
+	}
+	if ch.Description == "" {
+		ch.Description = f.Title
+	}
+	if u := f.Updated(); !u.IsZero() {
+		ch.LastBuildDate = u.UTC().Format(time.RFC1123Z)
+	}
+	if f.FeedURL != "" {
+		ch.AtomLink = &atomLink{
+			Href: f.FeedURL, Rel: "self", Type: FormatRSS.ContentType(),
+		}
+	}
+
+	for _, j := range f.Jobs {
+		item := &rssItem{
+			Title:       entryTitle(j),
+			Link:        JobURL(j),
+			Description: entryContent(j),
+			GUID:        &rssGUID{Value: EntryID(j)},
+		}
+		if !j.EnqueueTime.IsZero() {
+			item.PubDate = j.EnqueueTime.UTC().Format(time.RFC1123Z)
+		}
+		if encs := enclosures(j); len(encs) > 0 {
+			item.Enclosure = &rssEnclosure{
+				URL: encs[0].URL, Type: encs[0].Type,
+			}
+		}
+		ch.Items = append(ch.Items, item)
+	}
+
+	rf := &rssFeed{Version: "2.0", Channel: ch}
+	if ch.AtomLink != nil {
+		rf.AtomNS = "http://www.w3.org/2005/Atom"
+	}
+
+	return writeXML(w, rf)
+}
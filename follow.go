@@ -0,0 +1,67 @@
+package midjourney
+
+import (
+	"context"
+	"net/http"
+)
+
+type followUserRequest struct {
+	UserID string `json:"userId"`
+	Value  bool   `json:"value"`
+}
+
+// FollowUser follows the given user as the current user.
+func (c *Client) FollowUser(ctx context.Context, userID string) error {
+	return c.setUserFollowed(ctx, userID, true)
+}
+
+// UnfollowUser stops following the given user as the current user.
+func (c *Client) UnfollowUser(ctx context.Context, userID string) error {
+	return c.setUserFollowed(ctx, userID, false)
+}
+
+func (c *Client) setUserFollowed(
+	ctx context.Context,
+	userID string,
+	followed bool,
+) error {
+	if userID == "" {
+		return ErrUserIDRequired
+	}
+
+	return c.action(
+		ctx, http.MethodPost, "app/follow-user/", nil,
+		&followUserRequest{UserID: userID, Value: followed},
+	)
+}
+
+// Following returns all users followed by the current user.
+func (c *Client) Following(ctx context.Context) ([]*User, error) {
+	var users []*User
+
+	err := c.API.Get(ctx, "app/following/", nil, &users)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// FollowingFeed returns an iterator over the recent jobs of all users followed
+// by the current user, merged into a single stream, newest first.
+func (c *Client) FollowingFeed(ctx context.Context) (*MergedJobsIter, error) {
+	users, err := c.Following(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	iters := make([]*JobsIter, 0, len(users))
+	for _, u := range users {
+		if u == nil || u.ID == "" {
+			continue
+		}
+		iters = append(iters, c.RecentJobsIter(userJobsQuery(u.ID)))
+	}
+
+	return MergeJobsIters(iters...), nil
+}
@@ -0,0 +1,168 @@
+package midjourney
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFollowServer implements app/follow-user/, app/following/ and
+// app/recent-jobs for jobs of a given user, newest first.
+type fakeFollowServer struct {
+	*fakeServer
+	users     map[string]*User
+	following []string
+	jobs      map[string][]*Job
+}
+
+func (s *fakeFollowServer) followUser(w http.ResponseWriter, r *http.Request) {
+	var req followUserRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	if _, ok := s.users[req.UserID]; !ok {
+		_, _ = w.Write([]byte(`{"error":"user not found"}`))
+
+		return
+	}
+
+	following := []string{}
+	for _, id := range s.following {
+		if id != req.UserID {
+			following = append(following, id)
+		}
+	}
+	if req.Value {
+		following = append(following, req.UserID)
+	}
+	s.following = following
+	_, _ = w.Write([]byte(`{"success":true}`))
+}
+
+func (s *fakeFollowServer) listFollowing(
+	w http.ResponseWriter,
+	_ *http.Request,
+) {
+	users := []*User{}
+	for _, id := range s.following {
+		users = append(users, s.users[id])
+	}
+	_ = json.NewEncoder(w).Encode(users)
+}
+
+func newFakeFollowServer() *fakeFollowServer {
+	base := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) Time {
+		return Time{Time: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+
+	s := &fakeFollowServer{
+		fakeServer: newFakeServer(),
+		users: map[string]*User{
+			"u1": {ID: "u1", Username: "alice"},
+			"u2": {ID: "u2", Username: "bob"},
+			"u3": {ID: "u3", Username: "carol"},
+		},
+		jobs: map[string][]*Job{},
+	}
+
+	// u1 posts every 2 minutes, u2 every 3 minutes, u3 every minute.
+	for i := 0; i < 60; i++ {
+		s.jobs["u1"] = append(s.jobs["u1"], &Job{
+			ID: "u1-" + at(i*2).Format("1504"), UserID: "u1",
+			EnqueueTime: at(i * 2),
+		})
+		s.jobs["u2"] = append(s.jobs["u2"], &Job{
+			ID: "u2-" + at(i*3).Format("1504"), UserID: "u2",
+			EnqueueTime: at(i * 3),
+		})
+		s.jobs["u3"] = append(s.jobs["u3"], &Job{
+			ID: "u3-" + at(i).Format("1504"), UserID: "u3",
+			EnqueueTime: at(i),
+		})
+	}
+	for _, jobs := range s.jobs {
+		sort.Slice(jobs, func(i, j int) bool {
+			return jobs[i].EnqueueTime.After(jobs[j].EnqueueTime.Time)
+		})
+	}
+
+	s.handle("/app/follow-user/", s.followUser)
+	s.handle("/app/following/", s.listFollowing)
+	s.handle("/app/recent-jobs", recentJobsRoute(
+		func(q *RecentJobsQuery) []*Job {
+			return pageJobs(s.jobs[q.UserID], q)
+		},
+	))
+
+	return s
+}
+
+func TestClient_FollowUser(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeFollowServer()
+	c := newTestClient(t, srv)
+
+	require.NoError(t, c.FollowUser(ctx, "u1"))
+	require.NoError(t, c.FollowUser(ctx, "u2"))
+	require.NoError(t, c.UnfollowUser(ctx, "u1"))
+
+	users, err := c.Following(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*User{{ID: "u2", Username: "bob"}}, users)
+
+	var respErr *ResponseError
+	require.ErrorAs(t, c.FollowUser(ctx, "nope"), &respErr)
+	assert.Equal(t, "user not found", respErr.Message)
+
+	assert.ErrorIs(t, c.FollowUser(ctx, ""), ErrUserIDRequired)
+	assert.ErrorIs(t, c.UnfollowUser(ctx, ""), ErrUserIDRequired)
+}
+
+func TestClient_FollowingFeed(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeFollowServer()
+	srv.following = []string{"u1", "u2"}
+	c := newTestClient(t, srv)
+
+	it, err := c.FollowingFeed(ctx)
+	require.NoError(t, err)
+
+	jobs := []*Job{}
+	for it.Next(ctx) {
+		jobs = append(jobs, it.Job())
+	}
+	require.NoError(t, it.Err())
+
+	require.Len(t, jobs, 120)
+	assert.Equal(t, "u2", jobs[0].UserID)
+	for i := 1; i < len(jobs); i++ {
+		assert.False(t,
+			jobs[i].EnqueueTime.After(jobs[i-1].EnqueueTime.Time),
+			"job %d is newer than job %d", i, i-1,
+		)
+		assert.NotEqual(t, "u3", jobs[i].UserID)
+	}
+}
+
+func TestMergeJobsIters_Error(t *testing.T) {
+	ok := &JobsIter{page: []*Job{{ID: "a"}}, last: true}
+	failed := &JobsIter{err: ErrUserIDRequired}
+
+	it := MergeJobsIters(ok, failed)
+
+	assert.False(t, it.Next(context.Background()))
+	assert.ErrorIs(t, it.Err(), ErrUserIDRequired)
+}
+
+func TestMergeJobsIters_Empty(t *testing.T) {
+	it := MergeJobsIters()
+
+	assert.False(t, it.Next(context.Background()))
+	assert.NoError(t, it.Err())
+}
@@ -0,0 +1,106 @@
+package midjourney
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var fuzzSeeds = []string{
+	`{}`,
+	`null`,
+	`[]`,
+	`{"id":"a","grid_num":1,"ranking_by_user":"2","enqueue_time":1}`,
+	`{"enqueue_time":"2022-09-07T06:58:02Z","event":{"width":"a"}}`,
+	`{"event":null,"_parsed_params":{"version":"4"},"extra":[1,{}]}`,
+	`{"created":"2022-12-11 10:00:00+00","num_jobs":1e999,"data":{}}`,
+	`{"grid_num":{},"reference_image_num":[],"ranking_by_user":-1.5}`,
+	`{"enqueue_time":999999999999999}`,
+	`{"enqueue_time":-99999999999}`,
+}
+
+// fuzzRoundTrip decodes data into v, and if that succeeds ensures v can be
+// encoded and decoded again.
+func fuzzRoundTrip(t *testing.T, data []byte, v any, fresh func() any) {
+	if json.Unmarshal(data, v) != nil {
+		return
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal after successful unmarshal of %q: %s", data, err)
+	}
+
+	err = json.Unmarshal(b, fresh())
+	if err != nil {
+		t.Fatalf("unmarshal of marshaled %q: %s", b, err)
+	}
+}
+
+func FuzzJob_UnmarshalJSON(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add([]byte(s))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzRoundTrip(t, data, &Job{}, func() any { return &Job{} })
+		fuzzRoundTrip(t, data, &[]*Job{}, func() any { return &[]*Job{} })
+	})
+}
+
+func FuzzCollection_UnmarshalJSON(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add([]byte(s))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzRoundTrip(t, data, &Collection{}, func() any {
+			return &Collection{}
+		})
+	})
+}
+
+func FuzzTime_UnmarshalJSON(f *testing.F) {
+	for _, s := range []string{
+		`"2022-09-07 06:58:02.200753"`,
+		`"2022-09-07T06:58:02.200753+01:00"`,
+		`1662533882.2`,
+		`-1e15`,
+		`999999999999999`,
+		`-99999999999`,
+		`null`,
+		`""`,
+	} {
+		f.Add([]byte(s))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var ct Time
+		if ct.UnmarshalJSON(data) != nil {
+			return
+		}
+
+		b, err := ct.MarshalJSON()
+		if err != nil {
+			t.Fatalf("marshal after successful unmarshal of %q: %s", data, err)
+		}
+
+		err = (&Time{}).UnmarshalJSON(b)
+		if err != nil {
+			t.Fatalf("unmarshal of marshaled %q: %s", b, err)
+		}
+	})
+}
+
+func FuzzFlex_UnmarshalJSON(f *testing.F) {
+	for _, s := range []string{`1`, `"1"`, `1.5`, `true`, `null`, `"x"`} {
+		f.Add([]byte(s))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var fi FlexInt
+		_ = fi.UnmarshalJSON(data)
+
+		var fs FlexString
+		_ = fs.UnmarshalJSON(data)
+	})
+}
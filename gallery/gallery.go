@@ -0,0 +1,441 @@
+// Package gallery renders jobs and collections into a static HTML site.
+package gallery
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+// DefaultPerPage is the number of jobs shown per index page when Site.PerPage
+// is not set.
+const DefaultPerPage = 50
+
+var (
+	Err         = errors.New("gallery")
+	ErrNoOutDir = fmt.Errorf("%w: output directory required", Err)
+
+	// ErrInvalidJobID is returned by Site.Generate for jobs with IDs which
+	// can't be used as file names, as they are empty or contain path
+	// separators or "..".
+	ErrInvalidJobID = fmt.Errorf("%w: invalid job ID", Err)
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+var templates = template.Must(
+	template.New("").Funcs(template.FuncMap{
+		"truncate": truncate,
+		"rel":      rel,
+	}).ParseFS(templateFS, "templates/*"),
+)
+
+// Site is a static gallery of jobs.
+type Site struct {
+	Title       string
+	Description string
+	Jobs        []*midjourney.Job
+
+	// PerPage is the number of jobs shown on each index page.
+	PerPage int
+
+	// ThumbnailSize is the size of remote thumbnails used on index pages.
+	ThumbnailSize midjourney.ThumbnailSize
+
+	// ImageDir is an optional directory with local images named after
+	// Job.ImageFilename. Local images are copied into the site and used
+	// instead of remote image URLs.
+	ImageDir string
+}
+
+// CollectionSite returns a Site for the given collection and its jobs.
+func CollectionSite(
+	col *midjourney.Collection,
+	jobs []*midjourney.Job,
+) *Site {
+	title := col.Title
+	if title == "" {
+		title = col.ID
+	}
+
+	return &Site{
+		Title:       title,
+		Description: col.Description,
+		Jobs:        jobs,
+	}
+}
+
+type indexPage struct {
+	Site      *Site
+	Jobs      []*jobView
+	Page      int
+	Pages     int
+	PrevURL   string
+	NextURL   string
+	PageLinks []*pageLink
+}
+
+type pageLink struct {
+	Num     int
+	URL     string
+	Current bool
+}
+
+type jobView struct {
+	Job       *midjourney.Job
+	URL       string
+	Image     string
+	Thumbnail string
+	Params    []param
+	Parent    *jobLink
+	Children  []*jobLink
+}
+
+type jobLink struct {
+	ID   string
+	URL  string
+	Kind midjourney.LineageRelation
+}
+
+type param struct {
+	Name  string
+	Value string
+}
+
+type jobPage struct {
+	Site *Site
+	Job  *jobView
+	Root string
+}
+
+type searchEntry struct {
+	ID        string `json:"id"`
+	Prompt    string `json:"prompt"`
+	URL       string `json:"url"`
+	Thumbnail string `json:"thumbnail"`
+}
+
+// Generate writes the site into dir, creating it if needed.
+func (s *Site) Generate(ctx context.Context, dir string) error {
+	if dir == "" {
+		return ErrNoOutDir
+	}
+
+	for _, j := range s.Jobs {
+		if !validJobID(j.ID) {
+			return fmt.Errorf("%w: %q", ErrInvalidJobID, j.ID)
+		}
+	}
+
+	for _, d := range []string{dir, filepath.Join(dir, "jobs")} {
+		err := os.MkdirAll(d, 0o755)
+		if err != nil {
+			return err
+		}
+	}
+
+	views, err := s.jobViews(ctx, dir)
+	if err != nil {
+		return err
+	}
+
+	err = s.writeIndexPages(dir, views)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range views {
+		err = writeTemplate(
+			filepath.Join(dir, v.URL), "job.html",
+			&jobPage{Site: s, Job: v, Root: "../"},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.writeSearchIndex(dir, views)
+}
+
+func (s *Site) perPage() int {
+	if s.PerPage > 0 {
+		return s.PerPage
+	}
+
+	return DefaultPerPage
+}
+
+func (s *Site) thumbnailSize() midjourney.ThumbnailSize {
+	if s.ThumbnailSize > 0 {
+		return s.ThumbnailSize
+	}
+
+	return midjourney.ThumbnailSizeMedium
+}
+
+func (s *Site) jobViews(ctx context.Context, dir string) ([]*jobView, error) {
+	lineage := midjourney.NewLineage(nil)
+	err := lineage.Add(ctx, s.Jobs...)
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	for _, j := range s.Jobs {
+		known[j.ID] = true
+	}
+	link := func(n *midjourney.LineageNode) *jobLink {
+		l := &jobLink{ID: n.Job.ID}
+		if known[n.Job.ID] {
+			l.URL = jobURL(n.Job)
+		}
+
+		return l
+	}
+
+	views := make([]*jobView, 0, len(s.Jobs))
+	for _, j := range s.Jobs {
+		v := &jobView{
+			Job:       j,
+			URL:       "jobs/" + jobURL(j),
+			Image:     j.MainImageURL(),
+			Thumbnail: j.ThumbnailURL(s.thumbnailSize()),
+			Params:    params(j),
+		}
+
+		local, err := s.copyLocalImage(j, dir)
+		if err != nil {
+			return nil, err
+		}
+		if local != "" {
+			v.Image = local
+			v.Thumbnail = local
+		}
+
+		if n := lineage.Node(j.ID); n != nil {
+			if n.Parent != nil {
+				v.Parent = link(n.Parent)
+				v.Parent.Kind = n.Relation
+			}
+			for _, c := range n.Children {
+				l := link(c)
+				l.Kind = c.Relation
+				v.Children = append(v.Children, l)
+			}
+		}
+
+		views = append(views, v)
+	}
+
+	return views, nil
+}
+
+// copyLocalImage copies the local image of job into the site, and returns its
+// path relative to the site root. An empty string is returned if there is no
+// local image.
+func (s *Site) copyLocalImage(
+	j *midjourney.Job,
+	dir string,
+) (string, error) {
+	if s.ImageDir == "" {
+		return "", nil
+	}
+
+	name := j.ImageFilename()
+	src, err := os.Open(filepath.Join(s.ImageDir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	err = os.MkdirAll(filepath.Join(dir, "images"), 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	dst, err := os.Create(filepath.Join(dir, "images", name))
+	if err != nil {
+		return "", err
+	}
+
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		_ = dst.Close()
+
+		return "", err
+	}
+
+	return "images/" + name, dst.Close()
+}
+
+func (s *Site) writeIndexPages(dir string, views []*jobView) error {
+	per := s.perPage()
+	pages := (len(views) + per - 1) / per
+	if pages == 0 {
+		pages = 1
+	}
+
+	for i := 1; i <= pages; i++ {
+		start := (i - 1) * per
+		end := start + per
+		if end > len(views) {
+			end = len(views)
+		}
+
+		p := &indexPage{
+			Site:  s,
+			Jobs:  views[start:end],
+			Page:  i,
+			Pages: pages,
+		}
+		for n := 1; n <= pages; n++ {
+			p.PageLinks = append(p.PageLinks, &pageLink{
+				Num: n, URL: pageURL(n), Current: n == i,
+			})
+		}
+		if i > 1 {
+			p.PrevURL = pageURL(i - 1)
+		}
+		if i < pages {
+			p.NextURL = pageURL(i + 1)
+		}
+
+		err := writeTemplate(filepath.Join(dir, pageURL(i)), "index.html", p)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeSearchIndex writes a script file with the prompts of all jobs, allowing
+// client-side filtering across all pages, also when opened from the local
+// filesystem.
+func (s *Site) writeSearchIndex(dir string, views []*jobView) error {
+	entries := make([]*searchEntry, 0, len(views))
+	for _, v := range views {
+		entries = append(entries, &searchEntry{
+			ID:        v.Job.ID,
+			Prompt:    v.Job.Prompt,
+			URL:       v.URL,
+			Thumbnail: v.Thumbnail,
+		})
+	}
+
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(
+		filepath.Join(dir, "search.js"),
+		[]byte("window.galleryJobs = "+string(b)+";\n"),
+		0o644, //nolint:gosec
+	)
+}
+
+func writeTemplate(path string, name string, data any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = templates.ExecuteTemplate(f, name, data)
+	if err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
+
+func pageURL(page int) string {
+	if page <= 1 {
+		return "index.html"
+	}
+
+	return fmt.Sprintf("page-%d.html", page)
+}
+
+// validJobID reports if id is safe to use within file names.
+func validJobID(id string) bool {
+	return id != "" && !strings.Contains(id, "..") &&
+		!strings.ContainsAny(id, `/\`)
+}
+
+func jobURL(j *midjourney.Job) string {
+	return j.ID + ".html"
+}
+
+// params returns the parsed parameters of the job which are set, sorted by
+// name.
+func params(j *midjourney.Job) []param {
+	if j.ParsedParams == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(j.ParsedParams)
+	if err != nil {
+		return nil
+	}
+
+	m := map[string]any{}
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return nil
+	}
+
+	ps := make([]param, 0, len(m))
+	for k, v := range m {
+		var s string
+		switch x := v.(type) {
+		case string:
+			s = x
+		case []any:
+			parts := make([]string, 0, len(x))
+			for _, p := range x {
+				parts = append(parts, fmt.Sprint(p))
+			}
+			s = strings.Join(parts, ", ")
+		default:
+			s = fmt.Sprint(x)
+		}
+		ps = append(ps, param{Name: k, Value: s})
+	}
+	sort.Slice(ps, func(i, j int) bool { return ps[i].Name < ps[j].Name })
+
+	return ps
+}
+
+// rel returns path relative to root, unless path is an absolute URL.
+func rel(root string, path string) string {
+	if strings.Contains(path, "://") {
+		return path
+	}
+
+	return root + path
+}
+
+func truncate(n int, s string) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+
+	return string(r[:n]) + "…"
+}
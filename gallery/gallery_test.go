@@ -0,0 +1,157 @@
+package gallery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return string(b)
+}
+
+func TestSite_Generate(t *testing.T) {
+	grid := &midjourney.Job{
+		ID:                "grid",
+		Type:              midjourney.JobTypeGrid,
+		Prompt:            "a <b>bold</b> cat",
+		Username:          "jimeh",
+		Platform:          "discord",
+		GuildID:           "1",
+		PlatformChannelID: "2",
+		PlatformMessageID: "3",
+		ParsedParams: &midjourney.ParsedJobParams{
+			Aspect:  "16:9",
+			Version: "4",
+			No:      []string{"dogs", "birds"},
+		},
+	}
+	upscale := &midjourney.Job{
+		ID:                "upscale",
+		Type:              midjourney.JobTypeUpscale,
+		Prompt:            "a cat",
+		Username:          "jimeh",
+		ReferenceJobID:    "grid",
+		ReferenceImageNum: midjourney.NewFlexString("1"),
+	}
+	other := &midjourney.Job{
+		ID:             "other",
+		Type:           midjourney.JobTypeUpscale,
+		Prompt:         "a dog",
+		Username:       "jimeh",
+		ReferenceJobID: "elsewhere",
+	}
+
+	imageDir := t.TempDir()
+	err := os.WriteFile(
+		filepath.Join(imageDir, other.ImageFilename()), []byte("png"), 0o600,
+	)
+	require.NoError(t, err)
+
+	site := &Site{
+		Title:    "My Jobs",
+		Jobs:     []*midjourney.Job{grid, upscale, other},
+		PerPage:  2,
+		ImageDir: imageDir,
+	}
+	dir := filepath.Join(t.TempDir(), "site")
+
+	err = site.Generate(context.Background(), dir)
+	require.NoError(t, err)
+
+	index := readFile(t, filepath.Join(dir, "index.html"))
+	assert.Contains(t, index, "<title>My Jobs</title>")
+	assert.Contains(t, index, `href="jobs/grid.html"`)
+	assert.Contains(t, index, `href="jobs/upscale.html"`)
+	assert.NotContains(t, index, `href="jobs/other.html"`)
+	assert.Contains(t, index, "a &lt;b&gt;bold&lt;/b&gt; cat")
+	assert.Contains(t, index, grid.ThumbnailURL(midjourney.ThumbnailSizeMedium))
+	assert.Contains(t, index, `href="page-2.html"`)
+
+	page2 := readFile(t, filepath.Join(dir, "page-2.html"))
+	assert.Contains(t, page2, `href="jobs/other.html"`)
+	assert.Contains(t, page2, `src="images/`+other.ImageFilename()+`"`)
+	assert.Contains(t, page2, `href="index.html"`)
+
+	gridPage := readFile(t, filepath.Join(dir, "jobs", "grid.html"))
+	assert.Contains(t, gridPage, grid.MainImageURL())
+	assert.Contains(t, gridPage, grid.VideoURL())
+	assert.Contains(t, gridPage, grid.DiscordURL())
+	assert.Contains(t, gridPage, "<th>aspect</th><td>16:9</td>")
+	assert.Contains(t, gridPage, "<th>no</th><td>dogs, birds</td>")
+	assert.Contains(t, gridPage, `upscale: <a href="upscale.html">upscale</a>`)
+
+	upscalePage := readFile(t, filepath.Join(dir, "jobs", "upscale.html"))
+	assert.Contains(t, upscalePage, `upscale of <a href="grid.html">grid</a>`)
+	assert.NotContains(t, upscalePage, "<video")
+
+	otherPage := readFile(t, filepath.Join(dir, "jobs", "other.html"))
+	assert.Contains(t, otherPage, `src="../images/`+other.ImageFilename()+`"`)
+	assert.Contains(t, otherPage, "upscale of elsewhere")
+
+	_, err = os.Stat(filepath.Join(dir, "images", other.ImageFilename()))
+	require.NoError(t, err)
+
+	search := readFile(t, filepath.Join(dir, "search.js"))
+	assert.True(t, strings.HasPrefix(search, "window.galleryJobs = ["))
+	assert.Contains(t, search, `"prompt":"a dog"`)
+}
+
+func TestSite_Generate_NoOutDir(t *testing.T) {
+	err := (&Site{}).Generate(context.Background(), "")
+
+	assert.ErrorIs(t, err, ErrNoOutDir)
+}
+
+func TestSite_Generate_InvalidJobID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{name: "empty", id: ""},
+		{name: "parent", id: ".."},
+		{name: "slash", id: "../../etc/passwd"},
+		{name: "backslash", id: `a\b`},
+		{name: "nested", id: "a/b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			site := &Site{Jobs: []*midjourney.Job{{ID: "ok"}, {ID: tt.id}}}
+
+			err := site.Generate(context.Background(), dir)
+
+			assert.ErrorIs(t, err, ErrInvalidJobID)
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestCollectionSite(t *testing.T) {
+	jobs := []*midjourney.Job{{ID: "a"}}
+
+	site := CollectionSite(&midjourney.Collection{
+		ID:          "col",
+		Title:       "Cats",
+		Description: "All the cats",
+	}, jobs)
+
+	assert.Equal(t, &Site{
+		Title:       "Cats",
+		Description: "All the cats",
+		Jobs:        jobs,
+	}, site)
+}
@@ -0,0 +1,160 @@
+package midjourney
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	c, err := New(WithAPIURL(ts.URL), WithAuthToken("token"))
+	require.NoError(t, err)
+
+	return c
+}
+
+func newTestSubmitClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	c := newTestClient(t, handler)
+	require.NoError(t, c.Set(WithSubmitMode()))
+
+	return c
+}
+
+// fakeServer is an in-memory API server, which tests populate with only the
+// routes they need. Routes are called one at a time, so they can share state
+// without further locking. Requests to other paths get a 404 response.
+type fakeServer struct {
+	mux      sync.Mutex
+	routes   map[string]http.HandlerFunc
+	requests []string
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{routes: map[string]http.HandlerFunc{}}
+}
+
+// handle sets the route for requests to the given path.
+func (s *fakeServer) handle(path string, route http.HandlerFunc) {
+	s.routes[path] = route
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.requests = append(s.requests, r.URL.RequestURI())
+
+	route, ok := s.routes[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	route(w, r)
+}
+
+// recentJobsRoute returns a route for app/recent-jobs, which responds with the
+// jobs returned by fn for the request's query.
+func recentJobsRoute(fn func(q *RecentJobsQuery) []*Job) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q, _ := ParseRecentJobsQuery(r.URL.Query())
+		_ = json.NewEncoder(w).Encode(fn(q))
+	}
+}
+
+// pageJobs returns the page of jobs requested by q, counting pages from zero
+// like NextPage does.
+func pageJobs(jobs []*Job, q *RecentJobsQuery) []*Job {
+	start := q.Page * q.Amount
+	if start > len(jobs) {
+		return []*Job{}
+	}
+	end := start + q.Amount
+	if end > len(jobs) {
+		end = len(jobs)
+	}
+
+	return append([]*Job{}, jobs[start:end]...)
+}
+
+// fakeJobStatus implements app/job-status/. Each job has a list of states, and
+// every request returns the next state of each requested job, staying on the
+// last one. Unknown jobs are left out of responses, like the API does.
+type fakeJobStatus struct {
+	mux      sync.Mutex
+	states   map[string][]*Job
+	requests [][]string
+}
+
+// newFakeJobStatus returns a fakeJobStatus with a single state for each of the
+// given jobs.
+func newFakeJobStatus(jobs ...*Job) *fakeJobStatus {
+	f := &fakeJobStatus{states: map[string][]*Job{}}
+	for _, j := range jobs {
+		f.set(j.ID, j)
+	}
+
+	return f
+}
+
+// set replaces the states of the job with the given ID.
+func (f *fakeJobStatus) set(id string, states ...*Job) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.states == nil {
+		f.states = map[string][]*Job{}
+	}
+	f.states[id] = states
+}
+
+// job returns the current state of the job with the given ID, or nil if it is
+// unknown.
+func (f *fakeJobStatus) job(id string) *Job {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if states := f.states[id]; len(states) > 0 {
+		return states[0]
+	}
+
+	return nil
+}
+
+func (f *fakeJobStatus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	var req jobStatusRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	f.requests = append(f.requests, req.JobIDs)
+
+	jobs := []*Job{}
+	for _, id := range req.JobIDs {
+		states := f.states[id]
+		if len(states) == 0 {
+			continue
+		}
+		states[0].ID = id
+		jobs = append(jobs, states[0])
+		if len(states) > 1 {
+			f.states[id] = states[1:]
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(jobs)
+}
@@ -0,0 +1,97 @@
+package midjourney
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidImageURL = fmt.Errorf("%w: invalid image url", Err)
+
+var (
+	jobIDRegexp = regexp.MustCompile(
+		`^(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`,
+	)
+	gridFilenameRegexp = regexp.MustCompile(
+		`^grid_\d+(?:_\d+_N)?\.(?:png|webp|jpe?g)$`,
+	)
+	imageFilenameIndexRegexp = regexp.MustCompile(
+		`^\d+_(\d+)(?:_\d+_N)?\.(?:png|webp|jpe?g)$`,
+	)
+)
+
+var imageHosts = map[string]bool{
+	"mj-gallery.com":     true,
+	"cdn.midjourney.com": true,
+	"i.mj.run":           true,
+}
+
+// ImageRef identifies an image of a job, as referenced by an image URL.
+type ImageRef struct {
+	JobID string
+
+	// Grid is true when the URL refers to the grid of all images of a job.
+	Grid bool
+
+	// Video is true when the URL refers to the video of a grid job.
+	Video bool
+
+	// Index is the zero-based index of the image within the job's grid. It is
+	// always zero for grid images.
+	Index int
+}
+
+// ImageNum returns the one-based image number, as used by Variation and
+// Upscale, or zero for grid images and videos.
+func (r *ImageRef) ImageNum() int {
+	if r.Grid || r.Video {
+		return 0
+	}
+
+	return r.Index + 1
+}
+
+// ParseImageURL parses an image URL on mj-gallery.com, cdn.midjourney.com or
+// i.mj.run, like those returned by Job.MainImageURL and Job.ThumbnailURL. Video
+// URLs on i.mj.run, as returned by Job.VideoURL, are also accepted.
+func ParseImageURL(rawURL string) (*ImageRef, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImageURL, err)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if !imageHosts[host] {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImageURL, rawURL)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 2 || !jobIDRegexp.MatchString(parts[0]) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImageURL, rawURL)
+	}
+
+	ref := &ImageRef{JobID: strings.ToLower(parts[0])}
+	if host == DefaultMediaHost && parts[1] == "video.mp4" {
+		ref.Video = true
+
+		return ref, nil
+	}
+	if gridFilenameRegexp.MatchString(parts[1]) {
+		ref.Grid = true
+
+		return ref, nil
+	}
+
+	m := imageFilenameIndexRegexp.FindStringSubmatch(parts[1])
+	if m == nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImageURL, rawURL)
+	}
+	ref.Index, err = strconv.Atoi(m[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImageURL, rawURL)
+	}
+
+	return ref, nil
+}
@@ -0,0 +1,83 @@
+package midjourney
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImageURL(t *testing.T) {
+	id := "a3052616-372b-42a1-a72b-eb86fa0be633"
+	tests := []struct {
+		name    string
+		url     string
+		want    *ImageRef
+		wantErr error
+	}{
+		{
+			name: "main image",
+			url:  (&Job{ID: id}).MainImageURL(),
+			want: &ImageRef{JobID: id, Grid: true},
+		},
+		{
+			name: "thumbnail",
+			url:  (&Job{ID: id}).ThumbnailURL(ThumbnailSizeLarge),
+			want: &ImageRef{JobID: id, Grid: true},
+		},
+		{
+			name: "word image",
+			url:  (&Word{ImageID: id}).ImageURL(),
+			want: &ImageRef{JobID: id},
+		},
+		{
+			name: "cdn image thumbnail",
+			url:  "https://cdn.midjourney.com/" + id + "/0_3_384_N.webp",
+			want: &ImageRef{JobID: id, Index: 3},
+		},
+		{
+			name: "gallery image with query",
+			url:  "https://mj-gallery.com/" + id + "/0_1.png?v=2",
+			want: &ImageRef{JobID: id, Index: 1},
+		},
+		{
+			name: "video",
+			url:  (&Job{ID: id, Type: JobTypeGrid}).VideoURL(),
+			want: &ImageRef{JobID: id, Video: true},
+		},
+		{
+			name:    "video on other host",
+			url:     "https://mj-gallery.com/" + id + "/video.mp4",
+			wantErr: ErrInvalidImageURL,
+		},
+		{
+			name:    "invalid job id",
+			url:     "https://mj-gallery.com/foo/grid_0.png",
+			wantErr: ErrInvalidImageURL,
+		},
+		{
+			name:    "other host",
+			url:     "https://example.com/" + id + "/grid_0.png",
+			wantErr: ErrInvalidImageURL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseImageURL(tt.url)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestImageRef_ImageNum(t *testing.T) {
+	assert.Equal(t, 0, (&ImageRef{Grid: true}).ImageNum())
+	assert.Equal(t, 0, (&ImageRef{Video: true}).ImageNum())
+	assert.Equal(t, 1, (&ImageRef{}).ImageNum())
+	assert.Equal(t, 4, (&ImageRef{Index: 3}).ImageNum())
+}
@@ -0,0 +1,213 @@
+// Package imagegrid splits MidJourney grid images into their individual
+// tiles.
+package imagegrid
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+// DefaultBatchSize is the number of images in a grid when the job does not
+// specify a batch size.
+const DefaultBatchSize = 4
+
+var (
+	Err              = errors.New("imagegrid")
+	ErrInvalidLayout = fmt.Errorf("%w: invalid layout", Err)
+	ErrImageTooSmall = fmt.Errorf("%w: image too small for layout", Err)
+)
+
+// Layout describes how the tiles of a grid image are arranged. Tiles are
+// ordered left to right, top to bottom.
+type Layout struct {
+	Columns int
+	Rows    int
+	Count   int
+}
+
+// Valid reports if the layout can hold Count tiles.
+func (l Layout) Valid() bool {
+	return l.Columns > 0 && l.Rows > 0 && l.Count > 0 &&
+		l.Count <= l.Columns*l.Rows
+}
+
+// LayoutFor returns the layout of the grid image of the given job, based on
+// the job's batch size, and the given image bounds. The tile dimensions from
+// the job's event, or the aspect ratio from its parsed parameters, are used to
+// choose between candidate layouts.
+func LayoutFor(job *midjourney.Job, bounds image.Rectangle) Layout {
+	count := DefaultBatchSize
+	if job.Event != nil && job.Event.BatchSize.Int() > 0 {
+		count = job.Event.BatchSize.Int()
+	}
+
+	cols := int(math.Ceil(math.Sqrt(float64(count))))
+	def := Layout{
+		Columns: cols,
+		Rows:    (count + cols - 1) / cols,
+		Count:   count,
+	}
+
+	if w, h := tileSize(job); w > 0 && h > 0 {
+		// Full resolution grid, tile dimensions map exactly.
+		c := bounds.Dx() / w
+		r := bounds.Dy() / h
+		if c*w == bounds.Dx() && r*h == bounds.Dy() &&
+			c > 0 && r > 0 && c*r >= count {
+			return Layout{Columns: c, Rows: r, Count: count}
+		}
+	}
+
+	aspect := tileAspect(job)
+	if aspect <= 0 || bounds.Empty() {
+		return def
+	}
+
+	best := def
+	bestDiff := aspectDiff(bounds, def, aspect)
+	for c := 1; c <= count; c++ {
+		l := Layout{Columns: c, Rows: (count + c - 1) / c, Count: count}
+		diff := aspectDiff(bounds, l, aspect)
+		if diff < bestDiff-0.01 {
+			best, bestDiff = l, diff
+		}
+	}
+
+	return best
+}
+
+func aspectDiff(bounds image.Rectangle, l Layout, aspect float64) float64 {
+	w := float64(bounds.Dx()) / float64(l.Columns)
+	h := float64(bounds.Dy()) / float64(l.Rows)
+
+	return math.Abs(math.Log((w / h) / aspect))
+}
+
+// tileSize returns the dimensions of a single tile from the job's event, or
+// zero if unknown.
+func tileSize(job *midjourney.Job) (w, h int) {
+	if job.Event == nil {
+		return 0, 0
+	}
+
+	return job.Event.Width.Int(), job.Event.Height.Int()
+}
+
+// tileAspect returns the width/height ratio of a single tile, or zero if
+// unknown.
+func tileAspect(job *midjourney.Job) float64 {
+	if w, h := tileSize(job); w > 0 && h > 0 {
+		return float64(w) / float64(h)
+	}
+	if job.ParsedParams != nil {
+		return midjourney.ParseAspect(job.ParsedParams.Aspect)
+	}
+
+	return 0
+}
+
+// Split splits img into its tiles according to the given layout.
+func Split(img image.Image, layout Layout) ([]image.Image, error) {
+	if !layout.Valid() {
+		return nil, fmt.Errorf(
+			"%w: %dx%d with %d tiles",
+			ErrInvalidLayout, layout.Columns, layout.Rows, layout.Count,
+		)
+	}
+
+	b := img.Bounds()
+	w := b.Dx() / layout.Columns
+	h := b.Dy() / layout.Rows
+	if w == 0 || h == 0 {
+		return nil, ErrImageTooSmall
+	}
+
+	tiles := make([]image.Image, 0, layout.Count)
+	for i := 0; i < layout.Count; i++ {
+		x := b.Min.X + (i%layout.Columns)*w
+		y := b.Min.Y + (i/layout.Columns)*h
+		tiles = append(tiles, crop(img, image.Rect(x, y, x+w, y+h)))
+	}
+
+	return tiles, nil
+}
+
+func crop(img image.Image, r image.Rectangle) image.Image {
+	if si, ok := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}); ok {
+		return si.SubImage(r)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, r.Min, draw.Src)
+
+	return dst
+}
+
+// SplitJob decodes the grid PNG image of job read from r, and splits it into
+// its tiles.
+func SplitJob(job *midjourney.Job, r io.Reader) ([]image.Image, error) {
+	img, err := png.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return Split(img, LayoutFor(job, img.Bounds()))
+}
+
+// TileFilename returns the filename for the tile at the given zero-based
+// index, derived from the job's ImageFilename.
+func TileFilename(job *midjourney.Job, index int) string {
+	name := strings.TrimSuffix(job.ImageFilename(), ".png")
+
+	return fmt.Sprintf("%s_%d.png", name, index)
+}
+
+// WriteTiles writes the given tiles as PNG files into dir, and returns the
+// paths of the written files.
+func WriteTiles(
+	job *midjourney.Job,
+	tiles []image.Image,
+	dir string,
+) ([]string, error) {
+	paths := make([]string, 0, len(tiles))
+	for i, tile := range tiles {
+		path := filepath.Join(dir, TileFilename(job, i))
+
+		err := writePNG(path, tile)
+		if err != nil {
+			return paths, err
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = png.Encode(f, img)
+	if err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
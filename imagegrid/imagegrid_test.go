@@ -0,0 +1,180 @@
+package imagegrid
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tileColors = []color.RGBA{
+	{R: 255, A: 255},
+	{G: 255, A: 255},
+	{B: 255, A: 255},
+	{R: 255, G: 255, A: 255},
+}
+
+// gridImage returns an image made up of solid colored tiles of the given size.
+func gridImage(cols, rows, w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, cols*w, rows*h))
+	for y := 0; y < rows*h; y++ {
+		for x := 0; x < cols*w; x++ {
+			i := (y/h)*cols + x/w
+			img.Set(x, y, tileColors[i%len(tileColors)])
+		}
+	}
+
+	return img
+}
+
+func TestLayoutFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		job    *midjourney.Job
+		bounds image.Rectangle
+		want   Layout
+	}{
+		{
+			name:   "default",
+			job:    &midjourney.Job{},
+			bounds: image.Rect(0, 0, 1024, 1024),
+			want:   Layout{Columns: 2, Rows: 2, Count: 4},
+		},
+		{
+			name: "batch size 1",
+			job: &midjourney.Job{
+				Event: &midjourney.Event{
+					BatchSize: midjourney.NewFlexInt(1),
+				},
+			},
+			bounds: image.Rect(0, 0, 512, 512),
+			want:   Layout{Columns: 1, Rows: 1, Count: 1},
+		},
+		{
+			name: "batch size 2 with event dimensions",
+			job: &midjourney.Job{
+				Event: &midjourney.Event{
+					BatchSize: midjourney.NewFlexInt(2),
+					Width:     midjourney.NewFlexInt(512),
+					Height:    midjourney.NewFlexInt(512),
+				},
+			},
+			bounds: image.Rect(0, 0, 512, 1024),
+			want:   Layout{Columns: 1, Rows: 2, Count: 2},
+		},
+		{
+			name: "batch size 2 with aspect",
+			job: &midjourney.Job{
+				Event: &midjourney.Event{
+					BatchSize: midjourney.NewFlexInt(2),
+				},
+				ParsedParams: &midjourney.ParsedJobParams{Aspect: "1:1"},
+			},
+			bounds: image.Rect(0, 0, 256, 128),
+			want:   Layout{Columns: 2, Rows: 1, Count: 2},
+		},
+		{
+			name: "scaled down grid with event dimensions",
+			job: &midjourney.Job{
+				Event: &midjourney.Event{
+					Width:  midjourney.NewFlexInt(768),
+					Height: midjourney.NewFlexInt(512),
+				},
+			},
+			bounds: image.Rect(0, 0, 768, 512),
+			want:   Layout{Columns: 2, Rows: 2, Count: 4},
+		},
+		{
+			name: "batch size 3",
+			job: &midjourney.Job{
+				Event: &midjourney.Event{
+					BatchSize: midjourney.NewFlexInt(3),
+				},
+			},
+			bounds: image.Rect(0, 0, 1024, 1024),
+			want:   Layout{Columns: 2, Rows: 2, Count: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LayoutFor(tt.job, tt.bounds)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	img := gridImage(2, 2, 8, 4)
+
+	tiles, err := Split(img, Layout{Columns: 2, Rows: 2, Count: 4})
+	require.NoError(t, err)
+	require.Len(t, tiles, 4)
+
+	for i, tile := range tiles {
+		assert.Equal(t, 8, tile.Bounds().Dx())
+		assert.Equal(t, 4, tile.Bounds().Dy())
+
+		pt := tile.Bounds().Min
+		assert.Equal(t,
+			color.RGBAModel.Convert(tileColors[i]),
+			color.RGBAModel.Convert(tile.At(pt.X, pt.Y)),
+		)
+	}
+}
+
+func TestSplit_InvalidLayout(t *testing.T) {
+	_, err := Split(gridImage(1, 1, 4, 4), Layout{Columns: 1, Rows: 1})
+
+	assert.ErrorIs(t, err, ErrInvalidLayout)
+}
+
+func TestSplitJobAndWriteTiles(t *testing.T) {
+	job := &midjourney.Job{
+		ID:       "abc",
+		Username: "jimeh",
+		Prompt:   "a cat",
+		Event: &midjourney.Event{
+			Width:  midjourney.NewFlexInt(4),
+			Height: midjourney.NewFlexInt(4),
+		},
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, gridImage(2, 2, 4, 4))
+	require.NoError(t, err)
+
+	tiles, err := SplitJob(job, &buf)
+	require.NoError(t, err)
+	require.Len(t, tiles, 4)
+
+	dir := t.TempDir()
+	paths, err := WriteTiles(job, tiles, dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "jimeh_a_cat_abc_0.png"),
+		filepath.Join(dir, "jimeh_a_cat_abc_1.png"),
+		filepath.Join(dir, "jimeh_a_cat_abc_2.png"),
+		filepath.Join(dir, "jimeh_a_cat_abc_3.png"),
+	}, paths)
+
+	f, err := os.Open(paths[2])
+	require.NoError(t, err)
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 4, 4), img.Bounds())
+	assert.Equal(t,
+		color.RGBAModel.Convert(tileColors[2]),
+		color.RGBAModel.Convert(img.At(0, 0)),
+	)
+}
@@ -0,0 +1,382 @@
+// Package index provides a local full-text search index over MidJourney jobs,
+// allowing offline search of mirrored job data.
+package index
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/jimeh/go-midjourney/internal/jobfile"
+	"github.com/jimeh/go-midjourney/internal/text"
+)
+
+var (
+	Err      = errors.New("index")
+	ErrQuery = fmt.Errorf("%w: invalid query", Err)
+)
+
+// fieldGap is the position gap inserted between indexed fields, preventing
+// phrases from matching across fields.
+const fieldGap = 100
+
+// Index is an inverted index over job prompts, commands, parameters and
+// usernames. It is safe for concurrent use.
+type Index struct {
+	mux      sync.RWMutex
+	jobs     map[string]*midjourney.Job
+	postings map[string]map[string][]int
+
+	// files tracks the modification time of indexed files, see AddDir.
+	files map[string]time.Time
+}
+
+// New returns a new empty Index.
+func New() *Index {
+	return &Index{
+		jobs:     map[string]*midjourney.Job{},
+		postings: map[string]map[string][]int{},
+		files:    map[string]time.Time{},
+	}
+}
+
+// Len returns the number of indexed jobs.
+func (idx *Index) Len() int {
+	idx.mux.RLock()
+	defer idx.mux.RUnlock()
+
+	return len(idx.jobs)
+}
+
+// Job returns the indexed job with the given ID, or nil.
+func (idx *Index) Job(id string) *midjourney.Job {
+	idx.mux.RLock()
+	defer idx.mux.RUnlock()
+
+	return idx.jobs[id]
+}
+
+// Add adds or replaces the given jobs in the index.
+func (idx *Index) Add(jobs ...*midjourney.Job) {
+	idx.mux.Lock()
+	defer idx.mux.Unlock()
+
+	for _, j := range jobs {
+		if j == nil || j.ID == "" {
+			continue
+		}
+
+		idx.remove(j.ID)
+		idx.add(j)
+	}
+}
+
+// AddJSON reads and indexes jobs from r, which may contain a JSON array of
+// jobs, or a stream of job objects such as JSON Lines. It returns the number of
+// jobs added.
+func (idx *Index) AddJSON(r io.Reader) (int, error) {
+	n := 0
+	err := jobfile.Read(r, func(j *midjourney.Job) error {
+		idx.Add(j)
+		n++
+
+		return nil
+	})
+
+	return n, err
+}
+
+// Remove removes the job with the given ID from the index.
+func (idx *Index) Remove(id string) {
+	idx.mux.Lock()
+	defer idx.mux.Unlock()
+
+	idx.remove(id)
+}
+
+func (idx *Index) add(j *midjourney.Job) {
+	idx.jobs[j.ID] = j
+
+	pos := 0
+	for _, field := range jobFields(j) {
+		for _, term := range field {
+			docs, ok := idx.postings[term]
+			if !ok {
+				docs = map[string][]int{}
+				idx.postings[term] = docs
+			}
+			docs[j.ID] = append(docs[j.ID], pos)
+			pos++
+		}
+		pos += fieldGap
+	}
+}
+
+func (idx *Index) remove(id string) {
+	j, ok := idx.jobs[id]
+	if !ok {
+		return
+	}
+
+	for _, field := range jobFields(j) {
+		for _, term := range field {
+			docs := idx.postings[term]
+			delete(docs, id)
+			if len(docs) == 0 {
+				delete(idx.postings, term)
+			}
+		}
+	}
+	delete(idx.jobs, id)
+}
+
+// jobFields returns the terms of each indexed field of the job.
+func jobFields(j *midjourney.Job) [][]string {
+	fields := [][]string{
+		text.Tokenize(j.Prompt),
+		text.Tokenize(j.FullCommand),
+	}
+
+	if p := j.ParsedParams; p != nil {
+		var terms []string
+		terms = append(terms, text.Tokenize(p.Style)...)
+		terms = append(terms, text.Tokenize(p.Aspect)...)
+		for _, no := range p.No {
+			terms = append(terms, text.Tokenize(no)...)
+		}
+		fields = append(fields, terms)
+	}
+
+	if j.Username != "" {
+		fields = append(fields, []string{userTerm(j.Username)})
+	}
+
+	return fields
+}
+
+func userTerm(name string) string {
+	return "user:" + strings.ToLower(name)
+}
+
+// Filter restricts search results by job metadata. Zero value fields are
+// ignored.
+type Filter struct {
+	Version midjourney.AlgorithmVersion
+	Aspect  string
+	JobType midjourney.JobType
+	From    time.Time
+	To      time.Time
+
+	// Rankings matches jobs ranked with any of the given scores.
+	Rankings midjourney.RankedScores
+}
+
+// Match reports if the job matches the filter.
+func (f *Filter) Match(j *midjourney.Job) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.Version != "" &&
+		(j.ParsedParams == nil || j.ParsedParams.Version != f.Version) {
+		return false
+	}
+	if f.Aspect != "" && !sameAspect(f.Aspect, jobAspect(j)) {
+		return false
+	}
+	if f.JobType != "" && j.Type != f.JobType {
+		return false
+	}
+	if !f.From.IsZero() && j.EnqueueTime.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !j.EnqueueTime.Before(f.To) {
+		return false
+	}
+	if len(f.Rankings) > 0 {
+		found := false
+		for _, r := range f.Rankings {
+			if midjourney.RankedScore(j.RankingByUser.Int()) == r {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func jobAspect(j *midjourney.Job) string {
+	if j.ParsedParams != nil && j.ParsedParams.Aspect != "" {
+		return j.ParsedParams.Aspect
+	}
+
+	return "1:1"
+}
+
+func sameAspect(a, b string) bool {
+	x, y := midjourney.ParseAspect(a), midjourney.ParseAspect(b)
+
+	return x > 0 && math.Abs(x-y) < 0.001
+}
+
+// Result is a single search result.
+type Result struct {
+	Job   *midjourney.Job
+	Score float64
+}
+
+// Search returns all jobs matching the query string and filter, ordered by
+// relevance and then by most recent enqueue time.
+func (idx *Index) Search(query string, filter *Filter) ([]*Result, error) {
+	q, err := ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return idx.SearchQuery(q, filter), nil
+}
+
+// SearchQuery is like Search, but takes a parsed query.
+func (idx *Index) SearchQuery(q Query, filter *Filter) []*Result {
+	idx.mux.RLock()
+	defer idx.mux.RUnlock()
+
+	matches := idx.eval(q)
+
+	results := make([]*Result, 0, len(matches))
+	for id, score := range matches {
+		j := idx.jobs[id]
+		if !filter.Match(j) {
+			continue
+		}
+		results = append(results, &Result{Job: j, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.Job.EnqueueTime.Equal(b.Job.EnqueueTime.Time) {
+			return a.Job.EnqueueTime.After(b.Job.EnqueueTime.Time)
+		}
+
+		return a.Job.ID < b.Job.ID
+	})
+
+	return results
+}
+
+// eval returns the IDs of all jobs matching q, mapped to their score.
+func (idx *Index) eval(q Query) map[string]float64 {
+	switch q := q.(type) {
+	case *termQuery:
+		return idx.scoreTerm(idx.postings[q.term])
+	case *phraseQuery:
+		return idx.scoreTerm(idx.phrase(q.terms))
+	case *andQuery:
+		var result map[string]float64
+		for _, sub := range q.qs {
+			m := idx.eval(sub)
+			if result == nil {
+				result = m
+
+				continue
+			}
+			for id, s := range result {
+				if ms, ok := m[id]; ok {
+					result[id] = s + ms
+				} else {
+					delete(result, id)
+				}
+			}
+		}
+
+		return result
+	case *orQuery:
+		result := map[string]float64{}
+		for _, sub := range q.qs {
+			for id, s := range idx.eval(sub) {
+				result[id] += s
+			}
+		}
+
+		return result
+	case *notQuery:
+		exclude := idx.eval(q.q)
+		result := map[string]float64{}
+		for id := range idx.jobs {
+			if _, ok := exclude[id]; !ok {
+				result[id] = 0
+			}
+		}
+
+		return result
+	default:
+		result := make(map[string]float64, len(idx.jobs))
+		for id := range idx.jobs {
+			result[id] = 0
+		}
+
+		return result
+	}
+}
+
+// phrase returns the positions at which the given terms occur consecutively,
+// keyed by job ID.
+func (idx *Index) phrase(terms []string) map[string][]int {
+	result := map[string][]int{}
+
+	first := idx.postings[terms[0]]
+	for id, positions := range first {
+		var matched []int
+		for _, p := range positions {
+			ok := true
+			for i, term := range terms[1:] {
+				if !containsInt(idx.postings[term][id], p+i+1) {
+					ok = false
+
+					break
+				}
+			}
+			if ok {
+				matched = append(matched, p)
+			}
+		}
+		if len(matched) > 0 {
+			result[id] = matched
+		}
+	}
+
+	return result
+}
+
+func containsInt(s []int, v int) bool {
+	i := sort.SearchInts(s, v)
+
+	return i < len(s) && s[i] == v
+}
+
+// scoreTerm scores matching jobs with TF-IDF.
+func (idx *Index) scoreTerm(docs map[string][]int) map[string]float64 {
+	result := make(map[string]float64, len(docs))
+	if len(docs) == 0 {
+		return result
+	}
+
+	idf := math.Log(1 + float64(len(idx.jobs))/float64(len(docs)))
+	for id, positions := range docs {
+		result[id] = float64(len(positions)) * idf
+	}
+
+	return result
+}
@@ -0,0 +1,217 @@
+package index
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(d int) midjourney.Time {
+	return midjourney.Time{Time: time.Date(2022, 12, d, 0, 0, 0, 0, time.UTC)}
+}
+
+func testJobs() []*midjourney.Job {
+	return []*midjourney.Job{
+		{
+			ID:          "1",
+			Type:        midjourney.JobTypeGrid,
+			Prompt:      "an oil painting of a cat",
+			FullCommand: "an oil painting of a cat --ar 16:9 --v 4",
+			Username:    "jimeh",
+			EnqueueTime: day(1),
+			ParsedParams: &midjourney.ParsedJobParams{
+				Aspect: "16:9", Version: "4",
+			},
+		},
+		{
+			ID:            "2",
+			Type:          midjourney.JobTypeUpscale,
+			Prompt:        "a painting of oil and a dog",
+			Username:      "someone",
+			EnqueueTime:   day(2),
+			RankingByUser: midjourney.NewFlexInt(int(midjourney.Loved)),
+			ParsedParams: &midjourney.ParsedJobParams{
+				Version: "3", No: []string{"cats"},
+			},
+		},
+		{
+			ID:          "3",
+			Type:        midjourney.JobTypeGrid,
+			Prompt:      "cat cat cat",
+			Username:    "jimeh",
+			EnqueueTime: day(3),
+		},
+	}
+}
+
+func resultIDs(results []*Result) []string {
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.Job.ID)
+	}
+
+	return ids
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := New()
+	idx.Add(testJobs()...)
+
+	tests := []struct {
+		name   string
+		query  string
+		filter *Filter
+		want   []string
+	}{
+		{name: "all", query: "", want: []string{"3", "2", "1"}},
+		{name: "term by relevance", query: "cat", want: []string{"3", "1"}},
+		{name: "phrase", query: `"oil painting"`, want: []string{"1"}},
+		{name: "and", query: "oil painting", want: []string{"1", "2"}},
+		{name: "or", query: "dog OR cats", want: []string{"2"}},
+		{name: "not", query: "-cat", want: []string{"2"}},
+		{name: "user", query: "user:jimeh -oil", want: []string{"3"}},
+		{name: "params", query: `"16:9"`, want: []string{"1"}},
+		{name: "no match", query: "bird", want: []string{}},
+		{
+			name:   "version filter",
+			filter: &Filter{Version: "4"},
+			want:   []string{"1"},
+		},
+		{
+			name:   "aspect filter",
+			filter: &Filter{Aspect: "1:1"},
+			want:   []string{"3", "2"},
+		},
+		{
+			name:   "job type filter",
+			query:  "painting",
+			filter: &Filter{JobType: midjourney.JobTypeUpscale},
+			want:   []string{"2"},
+		},
+		{
+			name: "date range filter",
+			filter: &Filter{
+				From: day(2).Time,
+				To:   day(3).Time,
+			},
+			want: []string{"2"},
+		},
+		{
+			name: "ranking filter",
+			filter: &Filter{
+				Rankings: midjourney.RankedScores{midjourney.Loved},
+			},
+			want: []string{"2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := idx.Search(tt.query, tt.filter)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, resultIDs(got))
+		})
+	}
+}
+
+func TestIndex_AddReplacesAndRemove(t *testing.T) {
+	idx := New()
+	idx.Add(testJobs()...)
+
+	idx.Add(&midjourney.Job{ID: "1", Prompt: "a bird"})
+
+	got, err := idx.Search("painting", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2"}, resultIDs(got))
+
+	got, err = idx.Search("bird", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, resultIDs(got))
+
+	idx.Remove("1")
+	assert.Equal(t, 2, idx.Len())
+	assert.Nil(t, idx.Job("1"))
+
+	got, err = idx.Search("bird", nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestIndex_SaveAndLoad(t *testing.T) {
+	idx := New()
+	idx.Add(testJobs()...)
+
+	path := filepath.Join(t.TempDir(), "index.gob")
+	err := idx.SaveFile(path)
+	require.NoError(t, err)
+
+	loaded, err := Open(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, idx.Len(), loaded.Len())
+	assert.Equal(t, idx.Job("1"), loaded.Job("1"))
+
+	got, err := loaded.Search(`"oil painting" user:jimeh`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, resultIDs(got))
+}
+
+func TestOpen_NotExist(t *testing.T) {
+	idx, err := Open(filepath.Join(t.TempDir(), "nope"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, idx.Len())
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	_, err := Load(bytes.NewReader([]byte("nope")))
+
+	assert.Error(t, err)
+}
+
+func TestIndex_AddJSON(t *testing.T) {
+	idx := New()
+
+	n, err := idx.AddJSON(strings.NewReader(
+		`[{"id":"1","prompt":"a cat"},{"id":"2","prompt":"a dog"}]` + "\n" +
+			`{"id":"3","prompt":"a bird"}` + "\n",
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 3, idx.Len())
+}
+
+func TestIndex_AddDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600)
+		require.NoError(t, err)
+	}
+
+	write("a.json", `{"id":"1","prompt":"a cat"}`)
+	write("b.jsonl", `{"id":"2","prompt":"a dog"}`+"\n")
+	write("c.txt", `{"id":"3","prompt":"ignored"}`)
+
+	idx := New()
+	n, err := idx.AddDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	n, err = idx.AddDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n, "unchanged files should be skipped")
+
+	write("d.json", `{"id":"4","prompt":"a bird"}`)
+	n, err = idx.AddDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 3, idx.Len())
+}
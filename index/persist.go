@@ -0,0 +1,155 @@
+package index
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/jimeh/go-midjourney/internal/fileutil"
+	"github.com/jimeh/go-midjourney/internal/jobfile"
+)
+
+const persistVersion = 1
+
+var ErrVersion = fmt.Errorf("%w: unsupported index file version", Err)
+
+type persisted struct {
+	Version  int
+	Jobs     map[string][]byte
+	Postings map[string]map[string][]int
+	Files    map[string]time.Time
+}
+
+// Save writes the index to w. Jobs are stored in their JSON representation.
+func (idx *Index) Save(w io.Writer) error {
+	idx.mux.RLock()
+	defer idx.mux.RUnlock()
+
+	p := &persisted{
+		Version:  persistVersion,
+		Jobs:     make(map[string][]byte, len(idx.jobs)),
+		Postings: idx.postings,
+		Files:    idx.files,
+	}
+	for id, j := range idx.jobs {
+		b, err := json.Marshal(j)
+		if err != nil {
+			return err
+		}
+		p.Jobs[id] = b
+	}
+
+	return gob.NewEncoder(w).Encode(p)
+}
+
+// Load reads an index previously written with Save.
+func Load(r io.Reader) (*Index, error) {
+	p := &persisted{}
+	err := gob.NewDecoder(r).Decode(p)
+	if err != nil {
+		return nil, err
+	}
+	if p.Version != persistVersion {
+		return nil, fmt.Errorf("%w: %d", ErrVersion, p.Version)
+	}
+
+	idx := New()
+	for id, b := range p.Jobs {
+		j := &midjourney.Job{}
+		err = json.Unmarshal(b, j)
+		if err != nil {
+			return nil, err
+		}
+		idx.jobs[id] = j
+	}
+	if p.Postings != nil {
+		idx.postings = p.Postings
+	}
+	if p.Files != nil {
+		idx.files = p.Files
+	}
+
+	return idx, nil
+}
+
+// SaveFile writes the index to the given file, replacing it atomically.
+func (idx *Index) SaveFile(path string) error {
+	return fileutil.WriteAtomic(path, idx.Save)
+}
+
+// Open loads the index from the given file. If the file does not exist, a new
+// empty index is returned.
+func Open(path string) (*Index, error) {
+	idx, err := fileutil.Load(path, Load)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(), nil
+	}
+
+	return idx, err
+}
+
+// AddDir indexes all jobs in .json and .jsonl files within dir and its
+// subdirectories. Files which have not been modified since they were last
+// indexed are skipped, allowing the index to be updated incrementally as new
+// jobs are mirrored. It returns the number of jobs added.
+func (idx *Index) AddDir(dir string) (int, error) {
+	n := 0
+	err := filepath.WalkDir(dir, func(
+		path string,
+		d fs.DirEntry,
+		err error,
+	) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		if !jobfile.Match(path) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		idx.mux.RLock()
+		last, ok := idx.files[path]
+		idx.mux.RUnlock()
+		if ok && last.Equal(info.ModTime()) {
+			return nil
+		}
+
+		c, err := idx.addFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		n += c
+
+		idx.mux.Lock()
+		idx.files[path] = info.ModTime()
+		idx.mux.Unlock()
+
+		return nil
+	})
+
+	return n, err
+}
+
+func (idx *Index) addFile(path string) (int, error) {
+	n := 0
+	err := jobfile.ReadFile(path, func(j *midjourney.Job) error {
+		idx.Add(j)
+		n++
+
+		return nil
+	})
+
+	return n, err
+}
@@ -0,0 +1,301 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jimeh/go-midjourney/internal/text"
+)
+
+// Query is a parsed search query.
+//
+// The syntax supports terms, quoted phrases, "AND" (implied between terms),
+// "OR", "NOT" or a leading "-" for negation, and parentheses for grouping.
+// Terms prefixed with "user:" match usernames, for example:
+//
+//	cat OR dog -"oil painting" user:jimeh
+type Query interface {
+	String() string
+}
+
+type (
+	allQuery    struct{}
+	termQuery   struct{ term string }
+	phraseQuery struct{ terms []string }
+	notQuery    struct{ q Query }
+	andQuery    struct{ qs []Query }
+	orQuery     struct{ qs []Query }
+)
+
+func (allQuery) String() string     { return "*" }
+func (q *termQuery) String() string { return q.term }
+func (q *phraseQuery) String() string {
+	return `"` + strings.Join(q.terms, " ") + `"`
+}
+func (q *notQuery) String() string { return "NOT " + q.q.String() }
+func (q *andQuery) String() string { return joinQueries(q.qs, " AND ") }
+func (q *orQuery) String() string  { return joinQueries(q.qs, " OR ") }
+
+func joinQueries(qs []Query, sep string) string {
+	parts := make([]string, 0, len(qs))
+	for _, q := range qs {
+		parts = append(parts, q.String())
+	}
+
+	return "(" + strings.Join(parts, sep) + ")"
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokPhrase
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func lex(s string) ([]token, error) {
+	var toks []token
+	rs := []rune(s)
+
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			toks = append(toks, token{kind: tokLParen})
+			i++
+		case r == ')':
+			toks = append(toks, token{kind: tokRParen})
+			i++
+		case r == '-' && (i == 0 || unicode.IsSpace(rs[i-1]) ||
+			rs[i-1] == '('):
+			toks = append(toks, token{kind: tokNot})
+			i++
+		case r == '"':
+			j := i + 1
+			for j < len(rs) && rs[j] != '"' {
+				j++
+			}
+			if j == len(rs) {
+				return nil, fmt.Errorf("%w: unterminated phrase", ErrQuery)
+			}
+			toks = append(toks,
+				token{kind: tokPhrase, value: string(rs[i+1 : j])},
+			)
+			i = j + 1
+		default:
+			j := i
+			for j < len(rs) && !unicode.IsSpace(rs[j]) &&
+				rs[j] != '(' && rs[j] != ')' && rs[j] != '"' {
+				j++
+			}
+			word := string(rs[i:j])
+			switch word {
+			case "AND":
+				toks = append(toks, token{kind: tokAnd})
+			case "OR":
+				toks = append(toks, token{kind: tokOr})
+			case "NOT":
+				toks = append(toks, token{kind: tokNot})
+			default:
+				toks = append(toks, token{kind: tokWord, value: word})
+			}
+			i = j
+		}
+	}
+
+	return toks, nil
+}
+
+// ParseQuery parses a search query string. An empty query matches all jobs.
+func ParseQuery(s string) (Query, error) {
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 0 {
+		return allQuery{}, nil
+	}
+
+	p := &parser{toks: toks}
+	q, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, fmt.Errorf("%w: unexpected %s", ErrQuery, p.peekDesc())
+	}
+
+	return q, nil
+}
+
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.toks) {
+		return token{}, false
+	}
+
+	return p.toks[p.pos], true
+}
+
+func (p *parser) peekDesc() string {
+	t, ok := p.peek()
+	if !ok {
+		return "end of query"
+	}
+
+	switch t.kind {
+	case tokRParen:
+		return `")"`
+	case tokLParen:
+		return `"("`
+	case tokAnd:
+		return "AND"
+	case tokOr:
+		return "OR"
+	case tokNot:
+		return "NOT"
+	case tokWord, tokPhrase:
+		return fmt.Sprintf("%q", t.value)
+	default:
+		return "token"
+	}
+}
+
+func (p *parser) parseOr() (Query, error) {
+	q, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	qs := []Query{q}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != tokOr {
+			break
+		}
+		p.pos++
+
+		q, err = p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, q)
+	}
+
+	if len(qs) == 1 {
+		return qs[0], nil
+	}
+
+	return &orQuery{qs: qs}, nil
+}
+
+func (p *parser) parseAnd() (Query, error) {
+	q, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	qs := []Query{q}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind == tokOr || t.kind == tokRParen {
+			break
+		}
+		if t.kind == tokAnd {
+			p.pos++
+		}
+
+		q, err = p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, q)
+	}
+
+	if len(qs) == 1 {
+		return qs[0], nil
+	}
+
+	return &andQuery{qs: qs}, nil
+}
+
+func (p *parser) parseUnary() (Query, error) {
+	t, ok := p.peek()
+	if ok && t.kind == tokNot {
+		p.pos++
+		q, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notQuery{q: q}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Query, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected end of query", ErrQuery)
+	}
+
+	switch t.kind {
+	case tokLParen:
+		p.pos++
+		q, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := p.peek(); !ok || t.kind != tokRParen {
+			return nil, fmt.Errorf("%w: missing \")\"", ErrQuery)
+		}
+		p.pos++
+
+		return q, nil
+	case tokPhrase:
+		p.pos++
+
+		return textQuery(text.Tokenize(t.value)), nil
+	case tokWord:
+		p.pos++
+		if strings.HasPrefix(t.value, "user:") {
+			name := strings.TrimPrefix(t.value, "user:")
+
+			return &termQuery{term: userTerm(name)}, nil
+		}
+
+		return textQuery(text.Tokenize(t.value)), nil
+	case tokAnd, tokOr, tokNot, tokRParen:
+		return nil, fmt.Errorf("%w: unexpected %s", ErrQuery, p.peekDesc())
+	default:
+		return nil, fmt.Errorf("%w: unexpected %s", ErrQuery, p.peekDesc())
+	}
+}
+
+func textQuery(terms []string) Query {
+	switch len(terms) {
+	case 0:
+		return allQuery{}
+	case 1:
+		return &termQuery{term: terms[0]}
+	default:
+		return &phraseQuery{terms: terms}
+	}
+}
@@ -0,0 +1,55 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "", want: "*"},
+		{query: "cat", want: "cat"},
+		{query: "Cat Dog", want: "(cat AND dog)"},
+		{query: "cat AND dog", want: "(cat AND dog)"},
+		{query: "cat OR dog", want: "(cat OR dog)"},
+		{query: "cat dog OR bird", want: "((cat AND dog) OR bird)"},
+		{query: "cat -dog", want: "(cat AND NOT dog)"},
+		{query: "cat NOT dog", want: "(cat AND NOT dog)"},
+		{query: `"oil painting"`, want: `"oil painting"`},
+		{query: "16:10", want: `"16 10"`},
+		{query: "user:JimEh", want: "user:jimeh"},
+		{query: "(cat OR dog) bird", want: "((cat OR dog) AND bird)"},
+		{query: "half-life", want: `"half life"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestParseQuery_Invalid(t *testing.T) {
+	tests := []string{
+		`"unterminated`,
+		"(cat OR dog",
+		"cat )",
+		"cat OR",
+		"AND cat",
+		"-",
+	}
+	for _, query := range tests {
+		t.Run(query, func(t *testing.T) {
+			_, err := ParseQuery(query)
+
+			assert.ErrorIs(t, err, ErrQuery)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Package fileutil provides helpers for saving and loading files.
+package fileutil
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// WriteAtomic calls fn with a temporary file in the same directory as path,
+// and renames it to path once fn succeeds, so path is never left partially
+// written.
+func WriteAtomic(path string, fn func(io.Writer) error) error {
+	f, err := os.CreateTemp(
+		filepath.Dir(path), "."+filepath.Base(path)+"-*",
+	)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	err = fn(f)
+	if err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Load opens path and returns the result of calling fn with it.
+func Load[T any](path string, fn func(io.Reader) (T, error)) (T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		var zero T
+
+		return zero, err
+	}
+	defer f.Close()
+
+	return fn(f)
+}
@@ -0,0 +1,63 @@
+package fileutil
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.txt")
+
+	err := WriteAtomic(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "hello")
+
+		return err
+	})
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+
+	errWrite := errors.New("write failed")
+	err = WriteAtomic(path, func(w io.Writer) error {
+		_, _ = io.WriteString(w, "partial")
+
+		return errWrite
+	})
+	assert.ErrorIs(t, err, errWrite)
+
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b), "failed write should keep old file")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files should be removed")
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.txt")
+	err := os.WriteFile(path, []byte("hello"), 0o600)
+	require.NoError(t, err)
+
+	readAll := func(r io.Reader) (string, error) {
+		b, err := io.ReadAll(r)
+
+		return string(b), err
+	}
+
+	got, err := Load(path, readAll)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", got)
+
+	_, err = Load(path+".missing", readAll)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,91 @@
+// Package jobfile reads jobs from JSON and JSON Lines files.
+package jobfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+// Read decodes jobs from r, calling fn for each. r may contain a JSON array
+// of jobs, or a stream of job objects and arrays such as JSON Lines. Reading
+// stops at the first error, including errors returned by fn.
+func Read(r io.Reader, fn func(*midjourney.Job) error) error {
+	dec := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var jobs []*midjourney.Job
+		if strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+			err = json.Unmarshal(raw, &jobs)
+		} else {
+			j := &midjourney.Job{}
+			err = json.Unmarshal(raw, j)
+			jobs = append(jobs, j)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, j := range jobs {
+			err = fn(j)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ReadFile reads jobs from the given file with Read.
+func ReadFile(path string, fn func(*midjourney.Job) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return Read(f, fn)
+}
+
+// Match reports whether path has a .json or .jsonl extension, which ReadDir
+// reads jobs from.
+func Match(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	return ext == ".json" || ext == ".jsonl"
+}
+
+// ReadDir reads jobs from all .json and .jsonl files within dir and its
+// subdirectories with Read. Errors are prefixed with the path of the file they
+// occurred in.
+func ReadDir(dir string, fn func(*midjourney.Job) error) error {
+	return filepath.WalkDir(dir, func(
+		path string,
+		d fs.DirEntry,
+		err error,
+	) error {
+		if err != nil || d.IsDir() || !Match(path) {
+			return err
+		}
+
+		err = ReadFile(path, fn)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		return nil
+	})
+}
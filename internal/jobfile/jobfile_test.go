@@ -0,0 +1,111 @@
+package jobfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  []string{},
+		},
+		{
+			name:  "array",
+			input: `[{"id":"1"},{"id":"2"}]`,
+			want:  []string{"1", "2"},
+		},
+		{
+			name:  "json lines",
+			input: `{"id":"1"}` + "\n" + `{"id":"2"}` + "\n",
+			want:  []string{"1", "2"},
+		},
+		{
+			name:  "mixed",
+			input: `[{"id":"1"}]` + "\n" + `{"id":"2"}`,
+			want:  []string{"1", "2"},
+		},
+		{
+			name:    "invalid",
+			input:   `{"id":"1"}` + "\n" + `{"id":`,
+			want:    []string{"1"},
+			wantErr: "unexpected EOF",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := []string{}
+			r := strings.NewReader(tt.input)
+			err := Read(r, func(j *midjourney.Job) error {
+				ids = append(ids, j.ID)
+
+				return nil
+			})
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestRead_CallbackError(t *testing.T) {
+	errStop := errors.New("stop")
+	n := 0
+	err := Read(
+		strings.NewReader(`[{"id":"1"},{"id":"2"}]`),
+		func(j *midjourney.Job) error {
+			n++
+
+			return errStop
+		},
+	)
+
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, n)
+}
+
+func TestReadDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		err := os.MkdirAll(filepath.Dir(path), 0o700)
+		require.NoError(t, err)
+		err = os.WriteFile(path, []byte(content), 0o600)
+		require.NoError(t, err)
+	}
+
+	write("a.json", `[{"id":"1"}]`)
+	write("sub/b.JSONL", `{"id":"2"}`+"\n"+`{"id":"3"}`+"\n")
+	write("c.txt", `{"id":"4"}`)
+
+	ids := []string{}
+	err := ReadDir(dir, func(j *midjourney.Job) error {
+		ids = append(ids, j.ID)
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+
+	write("d.json", `{"id":`)
+	err = ReadDir(dir, func(j *midjourney.Job) error { return nil })
+	assert.ErrorContains(t, err, filepath.Join(dir, "d.json")+": ")
+}
@@ -0,0 +1,29 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{name: "empty", s: "", want: []string{}},
+		{name: "words", s: "A Cat, in a HAT!", want: []string{
+			"a", "cat", "in", "a", "hat",
+		}},
+		{name: "digits", s: "ar 16:9 v4", want: []string{
+			"ar", "16", "9", "v4",
+		}},
+		{name: "unicode", s: "café–noir", want: []string{"café", "noir"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Tokenize(tt.s))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package midjourney
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 )
@@ -16,8 +17,10 @@ const (
 type JobStatus string
 
 const (
+	JobStatusQueued    JobStatus = "queued"
 	JobStatusRunning   JobStatus = "running"
 	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
 )
 
 type Job struct {
@@ -30,7 +33,7 @@ type Job struct {
 	Flagged           bool             `json:"flagged,omitempty"`
 	FollowedByUser    bool             `json:"followed_by_user,omitempty"`
 	GridID            string           `json:"grid_id,omitempty"`
-	GridNum           string           `json:"grid_num,omitempty"`
+	GridNum           *FlexString      `json:"grid_num,omitempty"`
 	GuildID           string           `json:"guild_id,omitempty"`
 	Hidden            bool             `json:"hidden,omitempty"`
 	ID                string           `json:"id,omitempty"`
@@ -47,30 +50,50 @@ type Job struct {
 	PlatformThreadID  string           `json:"platform_thread_id,omitempty"`
 	Prompt            string           `json:"prompt,omitempty"`
 	RankedByUser      bool             `json:"ranked_by_user,omitempty"`
-	RankingByUser     int              `json:"ranking_by_user,omitempty"`
+	RankingByUser     *FlexInt         `json:"ranking_by_user,omitempty"`
 	Type              JobType          `json:"type,omitempty"`
 	UserID            string           `json:"user_id,omitempty"`
 	Username          string           `json:"username,omitempty"`
 	FullCommand       string           `json:"full_command,omitempty"`
 	ReferenceJobID    string           `json:"reference_job_id,omitempty"`
-	ReferenceImageNum string           `json:"reference_image_num,omitempty"`
+	ReferenceImageNum *FlexString      `json:"reference_image_num,omitempty"`
+
+	// Extra holds any JSON fields not modeled above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (j *Job) UnmarshalJSON(b []byte) error {
+	type job Job
+	extra, err := unmarshalWithExtra(b, (*job)(j))
+	if err != nil {
+		return err
+	}
+	j.Extra = extra
+
+	return nil
+}
+
+func (j Job) MarshalJSON() ([]byte, error) {
+	type job Job
+
+	return marshalWithExtra((*job)(&j), j.Extra)
+}
+
+func (j *Job) extraFields() map[string]json.RawMessage {
+	return j.Extra
 }
 
 func (j *Job) DiscordURL() string {
-	if j.Platform != "discord" || j.GuildID == "" ||
-		j.PlatformChannelID == "" || j.PlatformMessageID == "" {
+	m := j.DiscordMessage()
+	if m == nil {
 		return ""
 	}
 
-	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s",
-		j.GuildID,
-		j.PlatformChannelID,
-		j.PlatformMessageID,
-	)
+	return m.URL()
 }
 
 func (j *Job) MainImageURL() string {
-	return fmt.Sprintf("https://mj-gallery.com/%s/grid_0.png", j.ID)
+	return DefaultAssets.GridURL(j)
 }
 
 type ThumbnailSize int
@@ -82,9 +105,7 @@ const (
 )
 
 func (j *Job) ThumbnailURL(size ThumbnailSize) string {
-	return fmt.Sprintf(
-		"https://cdn.midjourney.com/%s/grid_0_%d_N.webp", j.ID, size,
-	)
+	return DefaultAssets.GridThumbnailURL(j, size, ImageFormatWebP)
 }
 
 var imageFilenameRegexp = regexp.MustCompile(`[^a-zA-Z0-9\._]+`)
@@ -100,20 +121,40 @@ func (j *Job) ImageFilename() string {
 }
 
 func (j *Job) VideoURL() string {
-	if j.Type != JobTypeGrid {
-		return ""
-	}
-
-	return fmt.Sprintf("https://i.mj.run/%s/video.mp4", j.ID)
+	return DefaultAssets.VideoURL(j)
 }
 
 type Event struct {
-	Height       int      `json:"height,omitempty"`
+	Height       *FlexInt `json:"height,omitempty"`
 	TextPrompt   []string `json:"textPrompt,omitempty"`
 	ImagePrompts []string `json:"imagePrompts,omitempty"`
-	Width        int      `json:"width,omitempty"`
-	BatchSize    int      `json:"batchSize,omitempty"`
+	Width        *FlexInt `json:"width,omitempty"`
+	BatchSize    *FlexInt `json:"batchSize,omitempty"`
 	SeedImageURL string   `json:"seedImageURL,omitempty"`
+
+	// Extra holds any JSON fields not modeled above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (e *Event) UnmarshalJSON(b []byte) error {
+	type event Event
+	extra, err := unmarshalWithExtra(b, (*event)(e))
+	if err != nil {
+		return err
+	}
+	e.Extra = extra
+
+	return nil
+}
+
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event
+
+	return marshalWithExtra((*event)(&e), e.Extra)
+}
+
+func (e *Event) extraFields() map[string]json.RawMessage {
+	return e.Extra
 }
 
 type ParsedJobParams struct {
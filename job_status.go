@@ -0,0 +1,58 @@
+package midjourney
+
+import (
+	"context"
+	"fmt"
+)
+
+var (
+	ErrJobIDRequired = fmt.Errorf("%w: job id required", Err)
+	ErrJobNotFound   = fmt.Errorf("%w: job", ErrNotFound)
+)
+
+type jobStatusRequest struct {
+	JobIDs []string `json:"jobIds"`
+}
+
+// JobStatus returns the current state of all given jobs. Jobs which cannot be
+// found are not included in the result.
+func (c *Client) JobStatus(
+	ctx context.Context,
+	jobIDs []string,
+) ([]*Job, error) {
+	if len(jobIDs) == 0 {
+		return nil, ErrJobIDsRequired
+	}
+
+	var jobs []*Job
+
+	err := c.API.Post(
+		ctx, "app/job-status/", nil,
+		&jobStatusRequest{JobIDs: jobIDs}, &jobs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// GetJob returns a single job by ID.
+func (c *Client) GetJob(ctx context.Context, jobID string) (*Job, error) {
+	if jobID == "" {
+		return nil, ErrJobIDRequired
+	}
+
+	jobs, err := c.JobStatus(ctx, []string{jobID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, j := range jobs {
+		if j != nil && j.ID == jobID {
+			return j, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: id=%s", ErrJobNotFound, jobID)
+}
@@ -67,19 +67,19 @@ func TestJob_UnmarshalJSON(t *testing.T) {
 					time.Date(2022, 9, 7, 6, 58, 2, 200753000, time.UTC),
 				},
 				Event: &Event{
-					Height: 512,
+					Height: NewFlexInt(512),
 					TextPrompt: []string{
 						"earth, landscape, picturesque, photo, photorealistic",
 					},
 					ImagePrompts: []string{},
-					Width:        768,
-					BatchSize:    1,
+					Width:        NewFlexInt(768),
+					BatchSize:    NewFlexInt(1),
 					SeedImageURL: "",
 				},
 				Flagged:        false,
 				FollowedByUser: false,
 				GridID:         "",
-				GridNum:        "",
+				GridNum:        nil,
 				GuildID:        "",
 				Hidden:         false,
 				ID:             "a3052616-372b-42a1-a72b-eb86fa0be633",
@@ -100,14 +100,14 @@ func TestJob_UnmarshalJSON(t *testing.T) {
 				Prompt: "earth, landscape, picturesque, photo, " +
 					"photorealistic",
 				RankedByUser:  false,
-				RankingByUser: 0,
+				RankingByUser: nil,
 				Type:          "grid",
 				UserID:        "146914681683050496",
 				Username:      "jimeh",
 				FullCommand: "earth, landscape, picturesque, photo, " +
 					"photorealistic --testp --ar 16:10  --video",
 				ReferenceJobID:    "",
-				ReferenceImageNum: "",
+				ReferenceImageNum: nil,
 			},
 		},
 	}
@@ -0,0 +1,172 @@
+package midjourney
+
+import "context"
+
+// JobsIter iterates over all jobs matching a RecentJobsQuery, fetching pages as
+// needed. Jobs which reappear on later pages, as results shift while
+// iterating, are skipped.
+//
+//	it := client.RecentJobsIter(query)
+//	for it.Next(ctx) {
+//		job := it.Job()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type JobsIter struct {
+	client *Client
+	query  *RecentJobsQuery
+	page   []*Job
+	job    *Job
+	seen   map[string]bool
+	last   bool
+	err    error
+
+	// prepare is called with the query before the first page is fetched.
+	prepare func(ctx context.Context, q *RecentJobsQuery) error
+}
+
+// RecentJobsIter returns an iterator over all pages of jobs matching q.
+func (c *Client) RecentJobsIter(q *RecentJobsQuery) *JobsIter {
+	qc := *q
+
+	return &JobsIter{client: c, query: &qc}
+}
+
+// Next advances the iterator to the next job, fetching the next page if
+// needed. It returns false when there are no more jobs, or an error occurred.
+func (it *JobsIter) Next(ctx context.Context) bool {
+	for it.err == nil {
+		for len(it.page) > 0 {
+			j := it.page[0]
+			it.page = it.page[1:]
+
+			if j == nil || it.seen[j.ID] {
+				continue
+			}
+			if it.seen == nil {
+				it.seen = map[string]bool{}
+			}
+			it.seen[j.ID] = true
+			it.job = j
+
+			return true
+		}
+
+		if it.last {
+			break
+		}
+		it.fetch(ctx)
+	}
+	it.job = nil
+
+	return false
+}
+
+func (it *JobsIter) fetch(ctx context.Context) {
+	if it.prepare != nil {
+		it.err = it.prepare(ctx, it.query)
+		it.prepare = nil
+		if it.err != nil {
+			return
+		}
+	}
+
+	rj, err := it.client.RecentJobs(ctx, it.query)
+	if err != nil {
+		it.err = err
+
+		return
+	}
+
+	it.page = rj.Jobs
+	it.last = len(rj.Jobs) == 0 ||
+		(it.query.Amount > 0 && len(rj.Jobs) < it.query.Amount)
+	it.query = rj.Query.NextPage()
+}
+
+// Job returns the current job.
+func (it *JobsIter) Job() *Job {
+	return it.job
+}
+
+// Err returns the first error encountered while iterating.
+func (it *JobsIter) Err() error {
+	return it.err
+}
+
+// MergedJobsIter merges multiple iterators which each yield jobs newest first
+// into a single stream ordered by enqueue time, newest first. Pages of each
+// iterator are only fetched when needed.
+type MergedJobsIter struct {
+	iters []*JobsIter
+	heads []*Job
+	done  []bool
+	seen  map[string]bool
+	job   *Job
+	err   error
+}
+
+// MergeJobsIters returns a new iterator merging the given iterators.
+func MergeJobsIters(iters ...*JobsIter) *MergedJobsIter {
+	return &MergedJobsIter{
+		iters: iters,
+		heads: make([]*Job, len(iters)),
+		done:  make([]bool, len(iters)),
+		seen:  map[string]bool{},
+	}
+}
+
+// Next advances the iterator to the next job. It returns false when all
+// iterators are exhausted, or any of them failed.
+func (it *MergedJobsIter) Next(ctx context.Context) bool {
+	for it.err == nil {
+		next := -1
+		for i, sub := range it.iters {
+			if it.heads[i] == nil && !it.done[i] {
+				if sub.Next(ctx) {
+					it.heads[i] = sub.Job()
+				} else {
+					it.done[i] = true
+					if err := sub.Err(); err != nil {
+						it.err = err
+
+						break
+					}
+				}
+			}
+
+			h := it.heads[i]
+			if h != nil && (next == -1 ||
+				h.EnqueueTime.After(it.heads[next].EnqueueTime.Time)) {
+				next = i
+			}
+		}
+		if it.err != nil || next == -1 {
+			break
+		}
+
+		j := it.heads[next]
+		it.heads[next] = nil
+		if it.seen[j.ID] {
+			continue
+		}
+		it.seen[j.ID] = true
+		it.job = j
+
+		return true
+	}
+	it.job = nil
+
+	return false
+}
+
+// Job returns the current job.
+func (it *MergedJobsIter) Job() *Job {
+	return it.job
+}
+
+// Err returns the first error encountered by any of the merged iterators.
+func (it *MergedJobsIter) Err() error {
+	return it.err
+}
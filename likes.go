@@ -0,0 +1,74 @@
+package midjourney
+
+import (
+	"context"
+	"net/http"
+)
+
+type likeJobRequest struct {
+	JobID string `json:"jobId"`
+	Value bool   `json:"value"`
+}
+
+// LikeJob likes the given job as the current user, adding it to their
+// bookmarks.
+func (c *Client) LikeJob(ctx context.Context, jobID string) error {
+	return c.setJobLiked(ctx, jobID, true)
+}
+
+// UnlikeJob removes the current user's like of the given job.
+func (c *Client) UnlikeJob(ctx context.Context, jobID string) error {
+	return c.setJobLiked(ctx, jobID, false)
+}
+
+// LikeJobs likes all given jobs, with at most concurrency requests in flight
+// at once. When concurrency is less than one, DefaultConcurrency is used. If
+// any job fails to be liked, a *BulkError is returned.
+func (c *Client) LikeJobs(
+	ctx context.Context,
+	jobIDs []string,
+	concurrency int,
+) error {
+	return c.bulkJobs(ctx, jobIDs, concurrency, c.LikeJob)
+}
+
+// UnlikeJobs removes likes of all given jobs, like LikeJobs.
+func (c *Client) UnlikeJobs(
+	ctx context.Context,
+	jobIDs []string,
+	concurrency int,
+) error {
+	return c.bulkJobs(ctx, jobIDs, concurrency, c.UnlikeJob)
+}
+
+func (c *Client) setJobLiked(
+	ctx context.Context,
+	jobID string,
+	liked bool,
+) error {
+	if jobID == "" {
+		return ErrJobIDRequired
+	}
+
+	return c.action(
+		ctx, http.MethodPost, "app/like-job/", nil,
+		&likeJobRequest{JobID: jobID, Value: liked},
+	)
+}
+
+// BookmarksIter returns an iterator over all jobs liked by the given user,
+// most recently liked first. When userID is empty, the authenticated user is
+// looked up when the iterator first fetches jobs.
+func (c *Client) BookmarksIter(userID string) *JobsIter {
+	it := c.RecentJobsIter(bookmarksQuery(userID))
+	if userID == "" {
+		it.prepare = func(ctx context.Context, q *RecentJobsQuery) error {
+			id, err := c.userIDOrMe(ctx, "")
+			q.UserIDLiked = id
+
+			return err
+		}
+	}
+
+	return it
+}
@@ -0,0 +1,168 @@
+package midjourney
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLikesServer implements app/like-job/, and app/recent-jobs for jobs
+// liked by the current user, most recently liked first.
+type fakeLikesServer struct {
+	*fakeServer
+	liked []string
+}
+
+func newFakeLikesServer(liked ...string) *fakeLikesServer {
+	s := &fakeLikesServer{fakeServer: newFakeServer(), liked: liked}
+	s.handle("/app/like-job/", s.likeJob)
+	s.handle("/app/recent-jobs", recentJobsRoute(
+		func(q *RecentJobsQuery) []*Job {
+			jobs := []*Job{}
+			for _, id := range s.liked {
+				jobs = append(jobs, &Job{ID: id, LikedByUser: true})
+			}
+
+			return pageJobs(jobs, q)
+		},
+	))
+
+	return s
+}
+
+func (s *fakeLikesServer) likeJob(w http.ResponseWriter, r *http.Request) {
+	var req likeJobRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	if strings.HasPrefix(req.JobID, "bad") {
+		_, _ = w.Write([]byte(`{"error":"job not found"}`))
+
+		return
+	}
+
+	s.unlike(req.JobID)
+	if req.Value {
+		s.liked = append([]string{req.JobID}, s.liked...)
+	}
+	_, _ = w.Write([]byte(`{"success":true}`))
+}
+
+func (s *fakeLikesServer) unlike(id string) {
+	for i, l := range s.liked {
+		if l == id {
+			s.liked = append(s.liked[:i], s.liked[i+1:]...)
+
+			return
+		}
+	}
+}
+
+func likedJobIDs(n int) []string {
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		ids = append(ids, fmt.Sprintf("job-%03d", i))
+	}
+
+	return ids
+}
+
+func TestClient_LikeJob(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeLikesServer()
+	c := newTestClient(t, srv)
+
+	require.NoError(t, c.LikeJob(ctx, "a"))
+	require.NoError(t, c.LikeJob(ctx, "b"))
+	assert.Equal(t, []string{"b", "a"}, srv.liked)
+
+	require.NoError(t, c.UnlikeJob(ctx, "b"))
+	assert.Equal(t, []string{"a"}, srv.liked)
+
+	var respErr *ResponseError
+	require.ErrorAs(t, c.LikeJob(ctx, "bad"), &respErr)
+	assert.Equal(t, "job not found", respErr.Message)
+
+	assert.ErrorIs(t, c.LikeJob(ctx, ""), ErrJobIDRequired)
+	assert.ErrorIs(t, c.UnlikeJob(ctx, ""), ErrJobIDRequired)
+}
+
+func TestClient_LikeJobs(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeLikesServer()
+	c := newTestClient(t, srv)
+
+	err := c.LikeJobs(ctx, []string{"a", "bad", "b", "c"}, 2)
+
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, []string{"bad"}, bulkErr.IDs())
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, srv.liked)
+
+	err = c.UnlikeJobs(ctx, []string{"a", "c"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, srv.liked)
+
+	assert.ErrorIs(t, c.LikeJobs(ctx, nil, 0), ErrJobIDsRequired)
+}
+
+func TestClient_BookmarksIter(t *testing.T) {
+	srv := newFakeLikesServer(likedJobIDs(120)...)
+	c := newTestClient(t, srv)
+
+	it := c.BookmarksIter("u1")
+	ids := []string{}
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Job().ID)
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, srv.liked, ids)
+	assert.Nil(t, it.Job())
+
+	require.Len(t, srv.requests, 3)
+	for i, req := range srv.requests {
+		assert.Contains(t, req, "orderBy=liked_timestamp")
+		assert.Contains(t, req, "userIdLiked=u1")
+		if i > 0 {
+			assert.Contains(t, req, "page="+strconv.Itoa(i))
+		}
+	}
+}
+
+func TestClient_BookmarksIter_SkipsShiftedJobs(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeLikesServer(likedJobIDs(60)...)
+	c := newTestClient(t, srv)
+
+	it := c.BookmarksIter("u1")
+	ids := []string{}
+	for it.Next(ctx) {
+		ids = append(ids, it.Job().ID)
+
+		// Liking a new job shifts all later pages by one.
+		if len(ids) == 1 {
+			require.NoError(t, c.LikeJob(ctx, "new"))
+		}
+	}
+	require.NoError(t, it.Err())
+
+	assert.Len(t, ids, 60)
+	assert.Equal(t, "job-059", ids[len(ids)-1])
+}
+
+func TestClient_BookmarksIter_NoAuthToken(t *testing.T) {
+	c, err := New()
+	require.NoError(t, err)
+
+	it := c.BookmarksIter("")
+
+	assert.False(t, it.Next(context.Background()))
+	assert.ErrorIs(t, it.Err(), ErrNoAuthToken)
+}
@@ -0,0 +1,335 @@
+package midjourney
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// JobGetter is implemented by types which can look up a single job by ID,
+// like Client.
+type JobGetter interface {
+	GetJob(ctx context.Context, jobID string) (*Job, error)
+}
+
+type LineageRelation string
+
+const (
+	LineageVariation LineageRelation = "variation"
+	LineageUpscale   LineageRelation = "upscale"
+)
+
+// LineageNode is a single job within a Lineage graph.
+type LineageNode struct {
+	Job      *Job
+	Parent   *LineageNode
+	Children []*LineageNode
+
+	// Relation describes how the job was derived from its parent.
+	Relation LineageRelation
+
+	// ImageNum is the index of the image within the parent job that this job
+	// was derived from.
+	ImageNum string
+
+	// Missing is true when the job is only known by its ID, as it is referenced
+	// by another job but could not be fetched.
+	Missing bool
+}
+
+// Lineage is a directed acyclic graph of jobs, connecting grids to the
+// variations and upscales derived from them.
+type Lineage struct {
+	getter JobGetter
+	nodes  map[string]*LineageNode
+}
+
+// NewLineage returns a new empty Lineage. When getter is not nil, it is used to
+// fetch parent jobs which have not been added to the graph.
+func NewLineage(getter JobGetter) *Lineage {
+	return &Lineage{
+		getter: getter,
+		nodes:  map[string]*LineageNode{},
+	}
+}
+
+// Add adds the given jobs to the graph, fetching any missing parent jobs.
+func (l *Lineage) Add(ctx context.Context, jobs ...*Job) error {
+	pending := make([]*LineageNode, 0, len(jobs))
+	for _, j := range jobs {
+		if j == nil || j.ID == "" {
+			continue
+		}
+		pending = append(pending, l.put(j))
+	}
+
+	for len(pending) > 0 {
+		n := pending[0]
+		pending = pending[1:]
+
+		parentID, imageNum := jobParent(n.Job)
+		if parentID == "" || n.Parent != nil {
+			continue
+		}
+
+		parent, ok := l.nodes[parentID]
+		if !ok {
+			j, err := l.fetch(ctx, parentID)
+			if err != nil {
+				return err
+			}
+
+			if j != nil {
+				parent = l.put(j)
+				pending = append(pending, parent)
+			} else {
+				parent = l.put(&Job{ID: parentID})
+				parent.Missing = true
+			}
+		}
+
+		if l.isAncestor(n, parent) {
+			continue
+		}
+
+		n.Parent = parent
+		n.ImageNum = imageNum
+		n.Relation = LineageVariation
+		if n.Job.Type == JobTypeUpscale {
+			n.Relation = LineageUpscale
+		}
+		parent.Children = append(parent.Children, n)
+	}
+
+	return nil
+}
+
+func (l *Lineage) put(j *Job) *LineageNode {
+	if j == nil {
+		return nil
+	}
+
+	n, ok := l.nodes[j.ID]
+	if !ok {
+		n = &LineageNode{}
+		l.nodes[j.ID] = n
+	}
+	n.Job = j
+	n.Missing = false
+
+	return n
+}
+
+// fetch returns the given job from the getter, or nil if there is no getter or
+// the job could not be found.
+func (l *Lineage) fetch(ctx context.Context, jobID string) (*Job, error) {
+	if l.getter == nil {
+		return nil, nil
+	}
+
+	j, err := l.getter.GetJob(ctx, jobID)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+
+	return j, err
+}
+
+// isAncestor reports if n is the same as or an ancestor of other.
+func (l *Lineage) isAncestor(n *LineageNode, other *LineageNode) bool {
+	for p := other; p != nil; p = p.Parent {
+		if p == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Node returns the node for the given job ID, or nil if the job is not part of
+// the graph.
+func (l *Lineage) Node(jobID string) *LineageNode {
+	return l.nodes[jobID]
+}
+
+// Len returns the number of jobs in the graph.
+func (l *Lineage) Len() int {
+	return len(l.nodes)
+}
+
+// Roots returns all nodes without a parent, sorted by job ID.
+func (l *Lineage) Roots() []*LineageNode {
+	roots := []*LineageNode{}
+	for _, n := range l.sortedNodes() {
+		if n.Parent == nil {
+			roots = append(roots, n)
+		}
+	}
+
+	return roots
+}
+
+// Ancestors returns all ancestors of the given job, starting with its direct
+// parent.
+func (l *Lineage) Ancestors(jobID string) []*Job {
+	n := l.nodes[jobID]
+	if n == nil {
+		return nil
+	}
+
+	jobs := []*Job{}
+	for p := n.Parent; p != nil; p = p.Parent {
+		jobs = append(jobs, p.Job)
+	}
+
+	return jobs
+}
+
+// Descendants returns all jobs derived from the given job, in breadth-first
+// order.
+func (l *Lineage) Descendants(jobID string) []*Job {
+	n := l.nodes[jobID]
+	if n == nil {
+		return nil
+	}
+
+	jobs := []*Job{}
+	queue := append([]*LineageNode{}, n.Children...)
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		jobs = append(jobs, c.Job)
+		queue = append(queue, c.Children...)
+	}
+
+	return jobs
+}
+
+func (l *Lineage) sortedNodes() []*LineageNode {
+	nodes := make([]*LineageNode, 0, len(l.nodes))
+	for _, n := range l.nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Job.ID < nodes[j].Job.ID
+	})
+
+	return nodes
+}
+
+// WriteDOT writes the graph in Graphviz DOT format to w.
+func (l *Lineage) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("digraph lineage {\n")
+	for _, n := range l.sortedNodes() {
+		attrs := fmt.Sprintf("label=%s", dotQuote(lineageLabel(n)))
+		if n.Missing {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(n.Job.ID), attrs)
+	}
+	for _, n := range l.sortedNodes() {
+		if n.Parent == nil {
+			continue
+		}
+		label := string(n.Relation)
+		if n.ImageNum != "" {
+			label += " " + n.ImageNum
+		}
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n",
+			dotQuote(n.Parent.Job.ID), dotQuote(n.Job.ID), dotQuote(label),
+		)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func lineageLabel(n *LineageNode) string {
+	if n.Missing || n.Job.Prompt == "" {
+		return n.Job.ID
+	}
+
+	prompt := n.Job.Prompt
+	if r := []rune(prompt); len(r) > 40 {
+		prompt = string(r[:40]) + "..."
+	}
+
+	return fmt.Sprintf("%s\n%s", n.Job.ID, prompt)
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+
+	return `"` + s + `"`
+}
+
+type lineageJSON struct {
+	Nodes []*lineageJSONNode `json:"nodes"`
+	Edges []*lineageJSONEdge `json:"edges"`
+}
+
+type lineageJSONNode struct {
+	ID      string  `json:"id"`
+	Type    JobType `json:"type,omitempty"`
+	Prompt  string  `json:"prompt,omitempty"`
+	Missing bool    `json:"missing,omitempty"`
+}
+
+type lineageJSONEdge struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Relation LineageRelation `json:"relation"`
+	ImageNum string          `json:"image_num,omitempty"`
+}
+
+// MarshalJSON returns the graph as a JSON object with a list of nodes and a
+// list of edges.
+func (l *Lineage) MarshalJSON() ([]byte, error) {
+	out := &lineageJSON{
+		Nodes: []*lineageJSONNode{},
+		Edges: []*lineageJSONEdge{},
+	}
+
+	for _, n := range l.sortedNodes() {
+		out.Nodes = append(out.Nodes, &lineageJSONNode{
+			ID:      n.Job.ID,
+			Type:    n.Job.Type,
+			Prompt:  n.Job.Prompt,
+			Missing: n.Missing,
+		})
+		if n.Parent != nil {
+			out.Edges = append(out.Edges, &lineageJSONEdge{
+				From:     n.Parent.Job.ID,
+				To:       n.Job.ID,
+				Relation: n.Relation,
+				ImageNum: n.ImageNum,
+			})
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// jobParent returns the ID of the job the given job was derived from, and the
+// index of the image within it.
+func jobParent(j *Job) (jobID string, imageNum string) {
+	if j.ReferenceJobID != "" && j.ReferenceJobID != j.ID {
+		return j.ReferenceJobID, j.ReferenceImageNum.String()
+	}
+	if j.GridID != "" && j.GridID != j.ID {
+		return j.GridID, j.GridNum.String()
+	}
+
+	return "", ""
+}
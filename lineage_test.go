@@ -0,0 +1,194 @@
+package midjourney
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapJobGetter map[string]*Job
+
+func (m mapJobGetter) GetJob(_ context.Context, id string) (*Job, error) {
+	j, ok := m[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: id=%s", ErrJobNotFound, id)
+	}
+
+	return j, nil
+}
+
+func jobIDs(jobs []*Job) []string {
+	ids := make([]string, 0, len(jobs))
+	for _, j := range jobs {
+		ids = append(ids, j.ID)
+	}
+
+	return ids
+}
+
+func TestLineage(t *testing.T) {
+	grid := &Job{ID: "grid", Type: JobTypeGrid, Prompt: "a cat"}
+	variation := &Job{
+		ID:                "variation",
+		Type:              JobTypeGrid,
+		ReferenceJobID:    "grid",
+		ReferenceImageNum: NewFlexString("2"),
+	}
+	upscale := &Job{
+		ID:                "upscale",
+		Type:              JobTypeUpscale,
+		ReferenceJobID:    "variation",
+		ReferenceImageNum: NewFlexString("1"),
+	}
+	gridUpscale := &Job{
+		ID:      "grid-upscale",
+		Type:    JobTypeUpscale,
+		GridID:  "grid",
+		GridNum: NewFlexString("3"),
+	}
+	orphan := &Job{
+		ID:             "orphan",
+		Type:           JobTypeUpscale,
+		ReferenceJobID: "unknown",
+	}
+
+	getter := mapJobGetter{"grid": grid}
+	l := NewLineage(getter)
+
+	err := l.Add(context.Background(), upscale, variation, gridUpscale, orphan)
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, l.Len())
+	assert.Equal(t,
+		[]string{"grid", "unknown"}, jobIDs(rootJobs(l.Roots())),
+	)
+	assert.True(t, l.Node("unknown").Missing)
+
+	assert.Equal(t,
+		[]string{"variation", "grid"}, jobIDs(l.Ancestors("upscale")),
+	)
+	assert.Empty(t, l.Ancestors("grid"))
+	assert.Nil(t, l.Ancestors("nope"))
+
+	assert.ElementsMatch(t,
+		[]string{"variation", "grid-upscale", "upscale"},
+		jobIDs(l.Descendants("grid")),
+	)
+
+	n := l.Node("upscale")
+	assert.Equal(t, LineageUpscale, n.Relation)
+	assert.Equal(t, "1", n.ImageNum)
+	assert.Equal(t, LineageVariation, l.Node("variation").Relation)
+	assert.Equal(t, "3", l.Node("grid-upscale").ImageNum)
+
+	var dot bytes.Buffer
+	err = l.WriteDOT(&dot)
+	require.NoError(t, err)
+	assert.Contains(t, dot.String(),
+		`"grid" -> "variation" [label="variation 2"];`,
+	)
+	assert.Contains(t, dot.String(),
+		`"variation" -> "upscale" [label="upscale 1"];`,
+	)
+	assert.Contains(t, dot.String(),
+		`"unknown" [label="unknown", style=dashed];`,
+	)
+
+	b, err := json.Marshal(l)
+	require.NoError(t, err)
+
+	var out struct {
+		Nodes []map[string]any `json:"nodes"`
+		Edges []map[string]any `json:"edges"`
+	}
+	err = json.Unmarshal(b, &out)
+	require.NoError(t, err)
+	assert.Len(t, out.Nodes, 6)
+	assert.Len(t, out.Edges, 4)
+}
+
+func TestLineage_AddResolvesPlaceholder(t *testing.T) {
+	ctx := context.Background()
+	l := NewLineage(nil)
+
+	err := l.Add(ctx, &Job{ID: "b", ReferenceJobID: "a"})
+	require.NoError(t, err)
+	assert.True(t, l.Node("a").Missing)
+
+	err = l.Add(ctx, &Job{ID: "a", Prompt: "foo"})
+	require.NoError(t, err)
+
+	a := l.Node("a")
+	assert.False(t, a.Missing)
+	assert.Equal(t, "foo", a.Job.Prompt)
+	assert.Equal(t, []string{"b"}, jobIDs(l.Descendants("a")))
+}
+
+func TestLineage_AddIgnoresCycles(t *testing.T) {
+	l := NewLineage(nil)
+
+	err := l.Add(context.Background(),
+		&Job{ID: "a", ReferenceJobID: "b"},
+		&Job{ID: "b", ReferenceJobID: "a"},
+	)
+	require.NoError(t, err)
+
+	assert.Len(t, l.Ancestors("a"), 1)
+	assert.Len(t, l.Ancestors("b"), 0)
+}
+
+func TestLineage_WriteDOTTruncatesPrompt(t *testing.T) {
+	tests := []struct {
+		name   string
+		prompt string
+		want   string
+	}{
+		{
+			name:   "short",
+			prompt: "a cat",
+			want:   `"a" [label="a\na cat"];`,
+		},
+		{
+			name:   "long",
+			prompt: strings.Repeat("a", 41),
+			want: `"a" [label="a\n` + strings.Repeat("a", 40) +
+				`..."];`,
+		},
+		{
+			name:   "non-ASCII",
+			prompt: strings.Repeat("猫", 41),
+			want: `"a" [label="a\n` + strings.Repeat("猫", 40) +
+				`..."];`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLineage(nil)
+			err := l.Add(context.Background(), &Job{ID: "a", Prompt: tt.prompt})
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			err = l.WriteDOT(&buf)
+			require.NoError(t, err)
+
+			assert.Contains(t, buf.String(), tt.want)
+			assert.True(t, utf8.ValidString(buf.String()))
+		})
+	}
+}
+
+func rootJobs(nodes []*LineageNode) []*Job {
+	jobs := make([]*Job, 0, len(nodes))
+	for _, n := range nodes {
+		jobs = append(jobs, n.Job)
+	}
+
+	return jobs
+}
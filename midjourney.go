@@ -1,5 +1,7 @@
-// Package midjourney provides a basic read-only API client for MidJourney. As
-// there is no official API, it uses the same API as the MidJourney website
-// uses, meaning that it is subject to change at any time, breaking this
-// package.
+// Package midjourney provides a basic API client for MidJourney. As there is
+// no official API, it uses the same API as the MidJourney website uses,
+// meaning that it is subject to change at any time, breaking this package.
+//
+// Enqueuing new jobs with Client.Submit is disabled by default, and must be
+// enabled explicitly with the WithSubmitMode option.
 package midjourney
@@ -0,0 +1,143 @@
+package midjourney
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidValue = fmt.Errorf("%w: invalid value", Err)
+
+// FlexString is a string which can be decoded from a JSON string, number or
+// boolean. Numbers and booleans are kept verbatim. Like other types, decoding
+// null leaves the value unchanged, so fields which may be null are declared as
+// *FlexString, which is nil for null.
+type FlexString string
+
+// NewFlexString returns a pointer to a FlexString holding s.
+func NewFlexString(s string) *FlexString {
+	fs := FlexString(s)
+
+	return &fs
+}
+
+// String returns the string, or an empty string if fs is nil.
+func (fs *FlexString) String() string {
+	if fs == nil {
+		return ""
+	}
+
+	return string(*fs)
+}
+
+func (fs *FlexString) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return fmt.Errorf("%w: string: empty input", ErrInvalidValue)
+	}
+
+	switch b[0] {
+	case 'n':
+		return nil
+	case '"':
+		var s string
+		err := json.Unmarshal(b, &s)
+		if err != nil {
+			return fmt.Errorf("%w: string: %s", ErrInvalidValue, err)
+		}
+		*fs = FlexString(s)
+	case '{', '[':
+		return fmt.Errorf("%w: string: %s", ErrInvalidValue, b)
+	default:
+		if !json.Valid(b) {
+			return fmt.Errorf("%w: string: %s", ErrInvalidValue, b)
+		}
+		*fs = FlexString(b)
+	}
+
+	return nil
+}
+
+// FlexInt is an int which can be decoded from a JSON number, numeric string or
+// boolean. Fractional numbers are truncated, and empty strings result in zero.
+// Like other types, decoding null leaves the value unchanged, so fields which
+// may be null are declared as *FlexInt, which is nil for null.
+type FlexInt int
+
+// NewFlexInt returns a pointer to a FlexInt holding n.
+func NewFlexInt(n int) *FlexInt {
+	fi := FlexInt(n)
+
+	return &fi
+}
+
+// Int returns the int, or zero if fi is nil.
+func (fi *FlexInt) Int() int {
+	if fi == nil {
+		return 0
+	}
+
+	return int(*fi)
+}
+
+func (fi *FlexInt) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return fmt.Errorf("%w: int: empty input", ErrInvalidValue)
+	}
+
+	s := string(b)
+	switch b[0] {
+	case 'n':
+		return nil
+	case 't', 'f':
+		var v bool
+		err := json.Unmarshal(b, &v)
+		if err != nil {
+			return fmt.Errorf("%w: int: %s", ErrInvalidValue, err)
+		}
+		*fi = 0
+		if v {
+			*fi = 1
+		}
+
+		return nil
+	case '"':
+		err := json.Unmarshal(b, &s)
+		if err != nil {
+			return fmt.Errorf("%w: int: %s", ErrInvalidValue, err)
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			*fi = 0
+
+			return nil
+		}
+	}
+
+	n, err := parseFlexInt(s)
+	if err != nil {
+		return err
+	}
+	*fi = FlexInt(n)
+
+	return nil
+}
+
+func parseFlexInt(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err == nil {
+		return n, nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) ||
+		f >= float64(math.MaxInt) || f <= float64(math.MinInt) {
+		return 0, fmt.Errorf("%w: int: %q", ErrInvalidValue, s)
+	}
+
+	return int(f), nil
+}
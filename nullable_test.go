@@ -0,0 +1,135 @@
+package midjourney
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlexString_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    FlexString
+		wantErr error
+	}{
+		{name: "string", json: `"3"`, want: "3"},
+		{name: "escaped string", json: `"a\"b"`, want: `a"b`},
+		{name: "int", json: `3`, want: "3"},
+		{name: "float", json: `1.5`, want: "1.5"},
+		{name: "bool", json: `true`, want: "true"},
+		{name: "null", json: `null`, want: "previous"},
+		{name: "object", json: `{"a":1}`, wantErr: ErrInvalidValue},
+		{name: "array", json: `[1]`, wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FlexString("previous")
+			err := got.UnmarshalJSON([]byte(tt.json))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFlexInt_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    FlexInt
+		wantErr error
+	}{
+		{name: "int", json: `5`, want: 5},
+		{name: "negative", json: `-2`, want: -2},
+		{name: "float", json: `4.9`, want: 4},
+		{name: "exponent", json: `1e3`, want: 1000},
+		{name: "string", json: `"5"`, want: 5},
+		{name: "padded string", json: `" 7 "`, want: 7},
+		{name: "empty string", json: `""`, want: 0},
+		{name: "null", json: `null`, want: 99},
+		{name: "true", json: `true`, want: 1},
+		{name: "false", json: `false`, want: 0},
+		{name: "word", json: `"five"`, wantErr: ErrInvalidValue},
+		{name: "huge", json: `1e300`, wantErr: ErrInvalidValue},
+		{name: "object", json: `{}`, wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FlexInt(99)
+			err := got.UnmarshalJSON([]byte(tt.json))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFlex_Null(t *testing.T) {
+	var v struct {
+		S *FlexString `json:"s,omitempty"`
+		I *FlexInt    `json:"i,omitempty"`
+	}
+
+	err := json.Unmarshal([]byte(`{"s":null,"i":null}`), &v)
+	require.NoError(t, err)
+	assert.Nil(t, v.S)
+	assert.Nil(t, v.I)
+	assert.Equal(t, "", v.S.String())
+	assert.Equal(t, 0, v.I.Int())
+
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(b))
+
+	err = json.Unmarshal([]byte(`{"s":"","i":0}`), &v)
+	require.NoError(t, err)
+	assert.Equal(t, NewFlexString(""), v.S)
+	assert.Equal(t, NewFlexInt(0), v.I)
+
+	b, err = json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"s":"","i":0}`, string(b))
+}
+
+func TestJob_UnmarshalJSONInconsistentTypes(t *testing.T) {
+	var j Job
+	err := json.Unmarshal([]byte(`{
+		"enqueue_time": "2022-09-07T06:58:02.200753Z",
+		"grid_num": 2,
+		"reference_image_num": "3",
+		"ranking_by_user": "4",
+		"event": {"width": "512", "height": 768.0, "batchSize": null}
+	}`), &j)
+	require.NoError(t, err)
+
+	assert.Equal(t, NewFlexString("2"), j.GridNum)
+	assert.Equal(t, NewFlexString("3"), j.ReferenceImageNum)
+	assert.Equal(t, NewFlexInt(4), j.RankingByUser)
+	assert.Equal(t, NewFlexInt(512), j.Event.Width)
+	assert.Equal(t, NewFlexInt(768), j.Event.Height)
+	assert.Nil(t, j.Event.BatchSize)
+	assert.Equal(t, 200753000, j.EnqueueTime.Nanosecond())
+
+	var col Collection
+	err = json.Unmarshal(
+		[]byte(`{"created":"2022-12-11 10:00:00+00","num_jobs":"12"}`), &col,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, NewFlexInt(12), col.NumJobs)
+	require.NotNil(t, col.Created)
+	assert.Equal(t, "2022-12-11 10:00:00", col.Created.UTC().Format(TimeFormat))
+}
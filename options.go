@@ -68,3 +68,26 @@ func WithLogger(logger zerolog.Logger) Option {
 		return nil
 	})
 }
+
+// WithStrictMode returns a new Option type which sets how the client reports
+// unknown fields in API responses. Unknown fields are always retained in the
+// Extra field of Job, Event and Collection values.
+func WithStrictMode(mode StrictMode) Option {
+	return optionFunc(func(c *APIClient) error {
+		c.StrictMode = mode
+
+		return nil
+	})
+}
+
+// WithSubmitMode returns a new Option type which enables Client.Submit to
+// enqueue new jobs. Without it, Submit returns ErrSubmitModeDisabled. Other
+// methods which change jobs or account state, like RankJob, LikeJob or
+// FollowUser, are not affected by this option.
+func WithSubmitMode() Option {
+	return optionFunc(func(c *APIClient) error {
+		c.SubmitMode = true
+
+		return nil
+	})
+}
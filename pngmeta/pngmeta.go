@@ -0,0 +1,415 @@
+// Package pngmeta embeds MidJourney job metadata into PNG images as text
+// chunks and an XMP packet, and extracts it again.
+//
+// Metadata is injected into the PNG chunk stream directly, meaning pixel data
+// is never decoded or re-encoded.
+package pngmeta
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+const pngSignature = "\x89PNG\r\n\x1a\n"
+
+// Keywords used for the text chunks written by Embed.
+const (
+	KeyTitle        = "Title"
+	KeyAuthor       = "Author"
+	KeyDescription  = "Description"
+	KeyCreationTime = "Creation Time"
+	KeySoftware     = "Software"
+	KeyJobID        = "MidJourney Job ID"
+	KeyUserID       = "MidJourney User ID"
+	KeyPrompt       = "MidJourney Prompt"
+	KeyFullCommand  = "MidJourney Full Command"
+	KeyEnqueueTime  = "MidJourney Enqueue Time"
+	KeyParameters   = "MidJourney Parameters"
+	KeyXMP          = "XML:com.adobe.xmp"
+)
+
+var (
+	Err           = errors.New("pngmeta")
+	ErrNotPNG     = fmt.Errorf("%w: not a PNG image", Err)
+	ErrCorrupt    = fmt.Errorf("%w: corrupt PNG chunk", Err)
+	ErrNoMetadata = fmt.Errorf("%w: no job metadata", Err)
+)
+
+// Embed copies the PNG image read from src to dst, adding text chunks and an
+// XMP packet describing job. Any metadata previously embedded by Embed is
+// replaced.
+func Embed(dst io.Writer, src io.Reader, job *midjourney.Job) error {
+	chunks, err := textChunks(job)
+	if err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(src)
+	err = readSignature(r)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(dst)
+	_, err = w.WriteString(pngSignature)
+	if err != nil {
+		return err
+	}
+
+	for {
+		c, err := readChunk(r)
+		if err != nil {
+			return err
+		}
+
+		if c.isText() && ownKeyword(c.keyword()) {
+			continue
+		}
+
+		err = writeChunk(w, c)
+		if err != nil {
+			return err
+		}
+
+		switch c.typ {
+		case "IHDR":
+			for _, tc := range chunks {
+				err = writeChunk(w, tc)
+				if err != nil {
+					return err
+				}
+			}
+		case "IEND":
+			return w.Flush()
+		}
+	}
+}
+
+// Text returns all text from tEXt, zTXt and iTXt chunks in the PNG image read
+// from r, keyed by keyword.
+func Text(r io.Reader) (map[string]string, error) {
+	br := bufio.NewReader(r)
+	err := readSignature(br)
+	if err != nil {
+		return nil, err
+	}
+
+	text := map[string]string{}
+	for {
+		c, err := readChunk(br)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.isText() {
+			k, v, err := c.text()
+			if err != nil {
+				return nil, err
+			}
+			text[k] = v
+		}
+
+		// Text chunks are allowed after the image data, so keep reading until
+		// the end.
+		if c.typ == "IEND" {
+			return text, nil
+		}
+	}
+}
+
+// Extract returns a summary of the job embedded in the PNG image read from r.
+// Only the fields written by Embed are populated.
+func Extract(r io.Reader) (*midjourney.Job, error) {
+	text, err := Text(r)
+	if err != nil {
+		return nil, err
+	}
+
+	id, ok := text[KeyJobID]
+	if !ok {
+		return nil, ErrNoMetadata
+	}
+
+	job := &midjourney.Job{
+		ID:          id,
+		UserID:      text[KeyUserID],
+		Username:    text[KeyAuthor],
+		Prompt:      text[KeyPrompt],
+		FullCommand: text[KeyFullCommand],
+	}
+
+	if v := text[KeyEnqueueTime]; v != "" {
+		err = job.EnqueueTime.UnmarshalJSON([]byte(v))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if v := text[KeyParameters]; v != "" {
+		job.ParsedParams = &midjourney.ParsedJobParams{}
+		err = json.Unmarshal([]byte(v), job.ParsedParams)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return job, nil
+}
+
+func textChunks(job *midjourney.Job) ([]*chunk, error) {
+	type entry struct{ key, value string }
+
+	entries := []entry{
+		{KeyTitle, job.Prompt},
+		{KeyAuthor, job.Username},
+		{KeyDescription, job.FullCommand},
+		{KeySoftware, "MidJourney"},
+		{KeyJobID, job.ID},
+		{KeyUserID, job.UserID},
+		{KeyPrompt, job.Prompt},
+		{KeyFullCommand, job.FullCommand},
+	}
+
+	if !job.EnqueueTime.IsZero() {
+		entries = append(entries,
+			entry{KeyCreationTime, job.EnqueueTime.UTC().Format(
+				"Mon, 02 Jan 2006 15:04:05 -0700",
+			)},
+			entry{KeyEnqueueTime, job.EnqueueTime.Format(
+				midjourney.TimeFormat,
+			)},
+		)
+	}
+
+	if job.ParsedParams != nil {
+		b, err := json.Marshal(job.ParsedParams)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry{KeyParameters, string(b)})
+	}
+
+	chunks := make([]*chunk, 0, len(entries)+1)
+	for _, e := range entries {
+		if e.value == "" {
+			continue
+		}
+		chunks = append(chunks, newTextChunk(e.key, e.value))
+	}
+	chunks = append(chunks, newITXtChunk(KeyXMP, xmpPacket(job)))
+
+	return chunks, nil
+}
+
+func ownKeyword(k string) bool {
+	switch k {
+	case KeyTitle, KeyAuthor, KeyDescription, KeyCreationTime, KeySoftware,
+		KeyJobID, KeyUserID, KeyPrompt, KeyFullCommand, KeyEnqueueTime,
+		KeyParameters, KeyXMP:
+		return true
+	default:
+		return false
+	}
+}
+
+type chunk struct {
+	typ  string
+	data []byte
+}
+
+func (c *chunk) isText() bool {
+	return c.typ == "tEXt" || c.typ == "zTXt" || c.typ == "iTXt"
+}
+
+func (c *chunk) keyword() string {
+	i := bytes.IndexByte(c.data, 0)
+	if i < 0 {
+		return ""
+	}
+
+	return latin1ToUTF8(c.data[:i])
+}
+
+func (c *chunk) text() (string, string, error) {
+	i := bytes.IndexByte(c.data, 0)
+	if i < 0 {
+		return "", "", ErrCorrupt
+	}
+	key := latin1ToUTF8(c.data[:i])
+	rest := c.data[i+1:]
+
+	switch c.typ {
+	case "tEXt":
+		return key, latin1ToUTF8(rest), nil
+	case "zTXt":
+		if len(rest) < 1 {
+			return "", "", ErrCorrupt
+		}
+		b, err := inflate(rest[1:])
+
+		return key, latin1ToUTF8(b), err
+	default: // iTXt
+		if len(rest) < 2 {
+			return "", "", ErrCorrupt
+		}
+		compressed := rest[0] == 1
+		rest = rest[2:]
+
+		// Skip language tag and translated keyword.
+		for n := 0; n < 2; n++ {
+			j := bytes.IndexByte(rest, 0)
+			if j < 0 {
+				return "", "", ErrCorrupt
+			}
+			rest = rest[j+1:]
+		}
+
+		if compressed {
+			b, err := inflate(rest)
+
+			return key, string(b), err
+		}
+
+		return key, string(rest), nil
+	}
+}
+
+func inflate(b []byte) ([]byte, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return io.ReadAll(zr)
+}
+
+// newTextChunk returns a tEXt chunk if value can be represented in Latin-1,
+// and an iTXt chunk otherwise.
+func newTextChunk(key, value string) *chunk {
+	b, ok := utf8ToLatin1(value)
+	if !ok {
+		return newITXtChunk(key, value)
+	}
+
+	data := make([]byte, 0, len(key)+1+len(b))
+	data = append(data, key...)
+	data = append(data, 0)
+	data = append(data, b...)
+
+	return &chunk{typ: "tEXt", data: data}
+}
+
+func newITXtChunk(key, value string) *chunk {
+	data := make([]byte, 0, len(key)+5+len(value))
+	data = append(data, key...)
+	// Null separator, compression flag, compression method, empty language
+	// tag and empty translated keyword.
+	data = append(data, 0, 0, 0, 0, 0)
+	data = append(data, value...)
+
+	return &chunk{typ: "iTXt", data: data}
+}
+
+func utf8ToLatin1(s string) ([]byte, bool) {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xff || r == utf8.RuneError {
+			return nil, false
+		}
+		b = append(b, byte(r))
+	}
+
+	return b, true
+}
+
+func latin1ToUTF8(b []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(b))
+	for _, c := range b {
+		sb.WriteRune(rune(c))
+	}
+
+	return sb.String()
+}
+
+func readSignature(r io.Reader) error {
+	sig := make([]byte, len(pngSignature))
+	_, err := io.ReadFull(r, sig)
+	if err != nil || string(sig) != pngSignature {
+		return ErrNotPNG
+	}
+
+	return nil
+}
+
+func readChunk(r io.Reader) (*chunk, error) {
+	var header [8]byte
+	_, err := io.ReadFull(r, header[:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCorrupt, err.Error())
+	}
+
+	n := binary.BigEndian.Uint32(header[:4])
+	if n > 0x7fffffff {
+		return nil, ErrCorrupt
+	}
+
+	c := &chunk{typ: string(header[4:8])}
+	var buf bytes.Buffer
+	_, err = io.CopyN(&buf, r, int64(n))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCorrupt, err.Error())
+	}
+	c.data = buf.Bytes()
+
+	var crc [4]byte
+	_, err = io.ReadFull(r, crc[:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCorrupt, err.Error())
+	}
+	if binary.BigEndian.Uint32(crc[:]) != c.crc() {
+		return nil, fmt.Errorf("%w: %s checksum mismatch", ErrCorrupt, c.typ)
+	}
+
+	return c, nil
+}
+
+func (c *chunk) crc() uint32 {
+	h := crc32.NewIEEE()
+	_, _ = h.Write([]byte(c.typ))
+	_, _ = h.Write(c.data)
+
+	return h.Sum32()
+}
+
+func writeChunk(w io.Writer, c *chunk) error {
+	var b [8]byte
+	binary.BigEndian.PutUint32(b[:4], uint32(len(c.data)))
+	copy(b[4:], c.typ)
+
+	_, err := w.Write(b[:])
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(c.data)
+	if err != nil {
+		return err
+	}
+
+	binary.BigEndian.PutUint32(b[:4], c.crc())
+	_, err = w.Write(b[:4])
+
+	return err
+}
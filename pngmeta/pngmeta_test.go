@@ -0,0 +1,121 @@
+package pngmeta
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	img.Set(1, 2, color.RGBA{R: 255, A: 255})
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	require.NoError(t, err)
+
+	return buf.Bytes()
+}
+
+func testJob() *midjourney.Job {
+	return &midjourney.Job{
+		ID:       "a3052616-372b-42a1-a72b-eb86fa0be633",
+		UserID:   "146914681683050496",
+		Username: "jimeh",
+		Prompt:   "earth, landscape, café <&> 日本",
+		FullCommand: "earth, landscape, café <&> 日本 " +
+			"--testp --ar 16:10",
+		EnqueueTime: midjourney.Time{
+			Time: time.Date(2022, 9, 7, 6, 58, 2, 200753000, time.UTC),
+		},
+		ParsedParams: &midjourney.ParsedJobParams{
+			Aspect:  "16:10",
+			Testp:   true,
+			Version: "4",
+		},
+	}
+}
+
+func TestEmbedAndExtract(t *testing.T) {
+	src := testPNG(t)
+	job := testJob()
+
+	var out bytes.Buffer
+	err := Embed(&out, bytes.NewReader(src), job)
+	require.NoError(t, err)
+
+	got, err := Extract(bytes.NewReader(out.Bytes()))
+	require.NoError(t, err)
+
+	assert.Equal(t, job, got)
+
+	text, err := Text(bytes.NewReader(out.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, "jimeh", text[KeyAuthor])
+	assert.Equal(t, "MidJourney", text[KeySoftware])
+	assert.Equal(t,
+		"Wed, 07 Sep 2022 06:58:02 +0000", text[KeyCreationTime],
+	)
+	assert.Contains(t, text[KeyXMP], `mj:JobID="`+job.ID+`"`)
+	assert.Contains(t, text[KeyXMP], "café &lt;&amp;&gt; 日本")
+
+	// Pixel data must be untouched.
+	want, err := png.Decode(bytes.NewReader(src))
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(out.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, want, img)
+}
+
+func TestEmbed_ReplacesExistingMetadata(t *testing.T) {
+	job := testJob()
+
+	var first bytes.Buffer
+	err := Embed(&first, bytes.NewReader(testPNG(t)), job)
+	require.NoError(t, err)
+
+	job.Prompt = "updated"
+	var second bytes.Buffer
+	err = Embed(&second, bytes.NewReader(first.Bytes()), job)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		bytes.Count(first.Bytes(), []byte(KeyJobID)),
+		bytes.Count(second.Bytes(), []byte(KeyJobID)),
+	)
+
+	got, err := Extract(bytes.NewReader(second.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, "updated", got.Prompt)
+}
+
+func TestExtract_NoMetadata(t *testing.T) {
+	_, err := Extract(bytes.NewReader(testPNG(t)))
+
+	assert.ErrorIs(t, err, ErrNoMetadata)
+}
+
+func TestEmbed_NotPNG(t *testing.T) {
+	var out bytes.Buffer
+	err := Embed(&out, bytes.NewReader([]byte("GIF89a")), testJob())
+
+	assert.ErrorIs(t, err, ErrNotPNG)
+}
+
+func TestText_Corrupt(t *testing.T) {
+	b := testPNG(t)
+	b[len(pngSignature)+10] ^= 0xff
+
+	_, err := Text(bytes.NewReader(b))
+
+	assert.ErrorIs(t, err, ErrCorrupt)
+}
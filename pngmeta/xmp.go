@@ -0,0 +1,66 @@
+package pngmeta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+)
+
+func xmpPacket(job *midjourney.Job) string {
+	var b bytes.Buffer
+
+	b.WriteString(`<?xpacket begin="` + "\ufeff" +
+		`" id="W5M0MpCehiHzreSzNTczkc9d"?>` + "\n")
+	b.WriteString(`<x:xmpmeta xmlns:x="adobe:ns:meta/">` + "\n")
+	b.WriteString(
+		` <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+			"\n",
+	)
+	b.WriteString(`  <rdf:Description rdf:about=""` + "\n" +
+		`    xmlns:dc="http://purl.org/dc/elements/1.1/"` + "\n" +
+		`    xmlns:xmp="http://ns.adobe.com/xap/1.0/"` + "\n" +
+		`    xmlns:mj="https://www.midjourney.com/ns/1.0/"` + "\n" +
+		`    xmp:CreatorTool="MidJourney"`)
+	xmpAttr(&b, "mj:JobID", job.ID)
+	xmpAttr(&b, "mj:UserID", job.UserID)
+	xmpAttr(&b, "mj:FullCommand", job.FullCommand)
+	if !job.EnqueueTime.IsZero() {
+		xmpAttr(&b, "xmp:CreateDate",
+			job.EnqueueTime.UTC().Format(time.RFC3339Nano),
+		)
+	}
+	b.WriteString(">\n")
+
+	if job.Prompt != "" {
+		b.WriteString(`   <dc:title><rdf:Alt><rdf:li xml:lang="x-default">`)
+		_ = xml.EscapeText(&b, []byte(job.Prompt))
+		b.WriteString("</rdf:li></rdf:Alt></dc:title>\n")
+		b.WriteString(
+			`   <dc:description><rdf:Alt><rdf:li xml:lang="x-default">`,
+		)
+		_ = xml.EscapeText(&b, []byte(job.Prompt))
+		b.WriteString("</rdf:li></rdf:Alt></dc:description>\n")
+	}
+	if job.Username != "" {
+		b.WriteString(`   <dc:creator><rdf:Seq><rdf:li>`)
+		_ = xml.EscapeText(&b, []byte(job.Username))
+		b.WriteString("</rdf:li></rdf:Seq></dc:creator>\n")
+	}
+
+	b.WriteString("  </rdf:Description>\n </rdf:RDF>\n</x:xmpmeta>\n")
+	b.WriteString(`<?xpacket end="r"?>`)
+
+	return b.String()
+}
+
+func xmpAttr(b *bytes.Buffer, name, value string) {
+	if value == "" {
+		return
+	}
+
+	b.WriteString("\n    " + name + `="`)
+	_ = xml.EscapeText(b, []byte(value))
+	b.WriteString(`"`)
+}
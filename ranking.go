@@ -0,0 +1,76 @@
+package midjourney
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+var ErrInvalidRankedScore = fmt.Errorf("%w: invalid ranked score", Err)
+
+type rankJobRequest struct {
+	JobID string      `json:"jobId"`
+	Value RankedScore `json:"value"`
+}
+
+// Valid returns true if rs is Unranked, or a score which can be given to a
+// job.
+func (rs RankedScore) Valid() bool {
+	switch rs {
+	case Unranked, Mehd, Liked, Loved:
+		return true
+	default:
+		return false
+	}
+}
+
+// Ranking returns the score the current user has given the job.
+func (j *Job) Ranking() RankedScore {
+	return RankedScore(j.RankingByUser.Int())
+}
+
+// RankJob sets the current user's ranking of the given job. Ranking a job as
+// Unranked clears any existing ranking.
+func (c *Client) RankJob(
+	ctx context.Context,
+	jobID string,
+	score RankedScore,
+) error {
+	if jobID == "" {
+		return ErrJobIDRequired
+	}
+	if !score.Valid() {
+		return fmt.Errorf("%w: %d", ErrInvalidRankedScore, score)
+	}
+
+	return c.action(
+		ctx, http.MethodPost, "app/rank-job/", nil,
+		&rankJobRequest{JobID: jobID, Value: score},
+	)
+}
+
+// ClearRanking removes the current user's ranking of the given job.
+func (c *Client) ClearRanking(ctx context.Context, jobID string) error {
+	return c.RankJob(ctx, jobID, Unranked)
+}
+
+// RankJobs sets the current user's ranking of all given jobs, with at most
+// concurrency requests in flight at once. When concurrency is less than one,
+// DefaultConcurrency is used. If any job fails to be ranked, a *BulkError is
+// returned.
+func (c *Client) RankJobs(
+	ctx context.Context,
+	jobIDs []string,
+	score RankedScore,
+	concurrency int,
+) error {
+	if !score.Valid() {
+		return fmt.Errorf("%w: %d", ErrInvalidRankedScore, score)
+	}
+
+	return c.bulkJobs(ctx, jobIDs, concurrency,
+		func(ctx context.Context, id string) error {
+			return c.RankJob(ctx, id, score)
+		},
+	)
+}
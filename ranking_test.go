@@ -0,0 +1,167 @@
+package midjourney
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRankServer records rankings posted to app/rank-job/, and fails requests
+// for job IDs starting with "bad". It also tracks the most requests in flight
+// at once, with each request taking at least delay.
+type fakeRankServer struct {
+	*fakeServer
+	rankings map[string]RankedScore
+
+	delay     time.Duration
+	flightMux sync.Mutex
+	inFlight  int
+	maxIn     int
+}
+
+func newFakeRankServer(delay time.Duration) *fakeRankServer {
+	s := &fakeRankServer{
+		fakeServer: newFakeServer(),
+		rankings:   map[string]RankedScore{},
+		delay:      delay,
+	}
+	s.handle("/app/rank-job/", s.rankJob)
+
+	return s
+}
+
+func (s *fakeRankServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.flightMux.Lock()
+	s.inFlight++
+	if s.inFlight > s.maxIn {
+		s.maxIn = s.inFlight
+	}
+	s.flightMux.Unlock()
+
+	time.Sleep(s.delay)
+	s.fakeServer.ServeHTTP(w, r)
+
+	s.flightMux.Lock()
+	s.inFlight--
+	s.flightMux.Unlock()
+}
+
+func (s *fakeRankServer) rankJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	var req rankJobRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	if strings.HasPrefix(req.JobID, "bad") {
+		_, _ = w.Write([]byte(`{"error":"job not found"}`))
+
+		return
+	}
+
+	s.rankings[req.JobID] = req.Value
+	_, _ = w.Write([]byte(`{"success":true}`))
+}
+
+func TestRankedScore_Valid(t *testing.T) {
+	for _, rs := range []RankedScore{Unranked, Mehd, Liked, Loved} {
+		assert.True(t, rs.Valid(), rs.String())
+	}
+	for _, rs := range []RankedScore{-1, 1, 3, 6} {
+		assert.False(t, rs.Valid(), rs.URIParam())
+	}
+}
+
+func TestJob_Ranking(t *testing.T) {
+	var j Job
+	err := json.Unmarshal([]byte(`{"ranking_by_user":"5"}`), &j)
+	require.NoError(t, err)
+
+	assert.Equal(t, Loved, j.Ranking())
+	assert.Equal(t, Unranked, (&Job{}).Ranking())
+}
+
+func TestClient_RankJob(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeRankServer(0)
+	c := newTestClient(t, srv)
+
+	err := c.RankJob(ctx, "a", Liked)
+	require.NoError(t, err)
+	assert.Equal(t, Liked, srv.rankings["a"])
+
+	err = c.ClearRanking(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, Unranked, srv.rankings["a"])
+
+	err = c.RankJob(ctx, "bad", Loved)
+	var respErr *ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "job not found", respErr.Message)
+
+	err = c.RankJob(ctx, "", Liked)
+	assert.ErrorIs(t, err, ErrJobIDRequired)
+
+	err = c.RankJob(ctx, "a", RankedScore(3))
+	assert.ErrorIs(t, err, ErrInvalidRankedScore)
+}
+
+func TestClient_RankJobs(t *testing.T) {
+	srv := newFakeRankServer(10 * time.Millisecond)
+	c := newTestClient(t, srv)
+
+	err := c.RankJobs(
+		context.Background(),
+		[]string{"a", "b", "bad1", "c", "d", "bad2", "e"}, Mehd, 2,
+	)
+
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, []string{"bad1", "bad2"}, bulkErr.IDs())
+	assert.ErrorIs(t, err, ErrResponse)
+
+	var respErr *ResponseError
+	assert.ErrorAs(t, err, &respErr)
+
+	assert.Equal(t, map[string]RankedScore{
+		"a": Mehd, "b": Mehd, "c": Mehd, "d": Mehd, "e": Mehd,
+	}, srv.rankings)
+	assert.LessOrEqual(t, srv.maxIn, 2)
+}
+
+func TestClient_RankJobs_Validation(t *testing.T) {
+	c, err := New()
+	require.NoError(t, err)
+
+	err = c.RankJobs(context.Background(), nil, Liked, 0)
+	assert.ErrorIs(t, err, ErrJobIDsRequired)
+
+	err = c.RankJobs(context.Background(), []string{"a"}, 7, 0)
+	assert.ErrorIs(t, err, ErrInvalidRankedScore)
+}
+
+func TestClient_RankJobs_Canceled(t *testing.T) {
+	srv := newFakeRankServer(0)
+	c := newTestClient(t, srv)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := c.RankJobs(ctx, []string{"a", "b"}, Liked, 1)
+
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, []string{"a", "b"}, bulkErr.IDs())
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, srv.rankings)
+}
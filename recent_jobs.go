@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
 const FromDateFormat = "2006-01-02 15:04:05.999999"
 
-var ErrUserIDRequired = fmt.Errorf("%w: user id required", Err)
+var (
+	ErrUserIDRequired = fmt.Errorf("%w: user id required", Err)
+	ErrInvalidQuery   = fmt.Errorf("%w: invalid query", Err)
+)
 
 type Order string
 
@@ -88,14 +92,66 @@ func (rjq *RecentJobsQuery) URLValues() url.Values {
 	return v
 }
 
+// ParseRecentJobsQuery parses URL values as produced by
+// RecentJobsQuery.URLValues back into a RecentJobsQuery.
+func ParseRecentJobsQuery(v url.Values) (*RecentJobsQuery, error) {
+	q := &RecentJobsQuery{
+		JobType:      JobType(v.Get("jobType")),
+		OrderBy:      Order(v.Get("orderBy")),
+		JobStatus:    JobStatus(v.Get("jobStatus")),
+		UserID:       v.Get("userId"),
+		UserIDLiked:  v.Get("userIdLiked"),
+		CollectionID: v.Get("collectionID"),
+		Prompt:       v.Get("prompt"),
+		Personal:     v.Get("personal") == "true",
+		Dedupe:       v.Get("dedupe") == "true",
+	}
+
+	var err error
+	ints := map[string]*int{
+		"amount":     &q.Amount,
+		"page":       &q.Page,
+		"refreshApi": &q.RefreshAPI,
+	}
+	for k, p := range ints {
+		if s := v.Get(k); s != "" {
+			*p, err = strconv.Atoi(s)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %s", ErrInvalidQuery, k, err)
+			}
+		}
+	}
+
+	if s := v.Get("user_id_ranked_score"); s != "" {
+		for _, score := range strings.Split(s, ",") {
+			n, err := strconv.Atoi(score)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"%w: user_id_ranked_score: %s", ErrInvalidQuery, err,
+				)
+			}
+			q.UserIDRankedScore = append(
+				q.UserIDRankedScore, RankedScore(n),
+			)
+		}
+	}
+
+	if s := v.Get("fromDate"); s != "" {
+		q.FromDate, err = time.Parse(FromDateFormat, s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: fromDate: %s", ErrInvalidQuery, err)
+		}
+	}
+
+	return q, nil
+}
+
+// NextPage returns a copy of the query for the page following it.
 func (rjq *RecentJobsQuery) NextPage() *RecentJobsQuery {
 	q := *rjq
 	if q.OrderBy == OrderNew && q.FromDate.IsZero() {
 		q.FromDate = time.Now().UTC()
 	}
-	if q.Page == 0 {
-		q.Page = 1
-	}
 	q.Page = rjq.Page + 1
 
 	return &q
@@ -131,22 +187,29 @@ func (c *Client) RecentJobs(
 	return rj, nil
 }
 
+// Home returns the most recent jobs of the given user, or of the authenticated
+// user when userID is empty.
 func (c *Client) Home(
 	ctx context.Context,
 	userID string,
 ) (*RecentJobs, error) {
-	if userID == "" {
-		return nil, ErrUserIDRequired
+	userID, err := c.userIDOrMe(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	return c.RecentJobs(ctx, &RecentJobsQuery{
+	return c.RecentJobs(ctx, userJobsQuery(userID))
+}
+
+func userJobsQuery(userID string) *RecentJobsQuery {
+	return &RecentJobsQuery{
 		Amount:    50,
 		JobType:   JobTypeNull,
 		OrderBy:   OrderNew,
 		JobStatus: JobStatusCompleted,
 		UserID:    userID,
 		Dedupe:    true,
-	})
+	}
 }
 
 func (c *Client) CommunityFeed(ctx context.Context) (*RecentJobs, error) {
@@ -170,22 +233,29 @@ func (c *Client) PersonalFeed(ctx context.Context) (*RecentJobs, error) {
 	})
 }
 
+// Bookmarks returns the jobs most recently liked by the given user, or by the
+// authenticated user when userID is empty.
 func (c *Client) Bookmarks(
 	ctx context.Context,
 	userID string,
 ) (*RecentJobs, error) {
-	if userID == "" {
-		return nil, ErrUserIDRequired
+	userID, err := c.userIDOrMe(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	return c.RecentJobs(ctx, &RecentJobsQuery{
+	return c.RecentJobs(ctx, bookmarksQuery(userID))
+}
+
+func bookmarksQuery(userID string) *RecentJobsQuery {
+	return &RecentJobsQuery{
 		Amount:      50,
 		JobType:     JobTypeNull,
 		OrderBy:     OrderLikedTime,
 		JobStatus:   JobStatusCompleted,
 		UserIDLiked: userID,
 		Dedupe:      true,
-	})
+	}
 }
 
 func (c *Client) CollectionFeed(
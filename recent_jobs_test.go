@@ -0,0 +1,101 @@
+package midjourney
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecentJobsQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   *RecentJobsQuery
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			query: &RecentJobsQuery{},
+		},
+		{
+			name: "full",
+			query: &RecentJobsQuery{
+				Amount:            50,
+				JobType:           JobTypeUpscale,
+				OrderBy:           OrderNew,
+				UserIDRankedScore: RankedScores{Liked, Loved},
+				JobStatus:         JobStatusCompleted,
+				UserID:            "123",
+				UserIDLiked:       "456",
+				CollectionID:      "789",
+				FromDate: time.Date(
+					2022, 12, 11, 10, 30, 15, 123456000, time.UTC,
+				),
+				Page:       3,
+				Prompt:     "a cat",
+				Personal:   true,
+				Dedupe:     true,
+				RefreshAPI: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRecentJobsQuery(tt.query.URLValues())
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.query, got)
+		})
+	}
+}
+
+func TestParseRecentJobsQuery_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		values url.Values
+	}{
+		{
+			name:   "amount",
+			values: url.Values{"amount": {"many"}},
+		},
+		{
+			name:   "ranked score",
+			values: url.Values{"user_id_ranked_score": {"4,x"}},
+		},
+		{
+			name:   "from date",
+			values: url.Values{"fromDate": {"yesterday"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRecentJobsQuery(tt.values)
+
+			assert.ErrorIs(t, err, ErrInvalidQuery)
+		})
+	}
+}
+
+func TestRecentJobsQuery_NextPage(t *testing.T) {
+	tests := []struct {
+		name string
+		page int
+		want int
+	}{
+		{name: "zero", page: 0, want: 1},
+		{name: "first", page: 1, want: 2},
+		{name: "second", page: 2, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &RecentJobsQuery{Page: tt.page, OrderBy: OrderHot}
+
+			got := q.NextPage()
+
+			assert.Equal(t, tt.want, got.Page)
+			assert.Equal(t, tt.page, q.Page)
+		})
+	}
+}
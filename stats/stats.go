@@ -0,0 +1,294 @@
+// Package stats computes usage statistics over a history of MidJourney jobs.
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/jimeh/go-midjourney/internal/text"
+)
+
+// DefaultTopTerms is the number of prompt terms included in Stats.TopTerms
+// when not otherwise specified.
+const DefaultTopTerms = 25
+
+// Stats are aggregated usage statistics over a set of jobs.
+type Stats struct {
+	Total     int            `json:"total"`
+	PerDay    map[string]int `json:"per_day"`
+	PerWeek   map[string]int `json:"per_week"`
+	JobTypes  map[string]int `json:"job_types"`
+	Versions  map[string]int `json:"versions"`
+	Aspects   map[string]int `json:"aspects"`
+	Fast      int            `json:"fast"`
+	Relaxed   int            `json:"relaxed"`
+	Metered   int            `json:"metered"`
+	FastParam int            `json:"fast_param"`
+
+	Grids    int `json:"grids"`
+	Upscales int `json:"upscales"`
+
+	// UpscaleRatio is the number of upscales per grid job.
+	UpscaleRatio float64 `json:"upscale_ratio"`
+
+	TopTerms []*TermCount `json:"top_terms"`
+}
+
+// TermCount is the number of jobs whose prompt includes a term.
+type TermCount struct {
+	Term  string `json:"term"`
+	Count int    `json:"count"`
+}
+
+// Collector accumulates statistics for jobs as they are added. It is safe for
+// concurrent use.
+type Collector struct {
+	// TopTerms is the number of most frequent prompt terms to include in the
+	// result. Defaults to DefaultTopTerms when zero.
+	TopTerms int
+
+	mux   sync.Mutex
+	seen  map[string]bool
+	stats *Stats
+	terms map[string]int
+}
+
+// NewCollector returns a new empty Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		seen: map[string]bool{},
+		stats: &Stats{
+			PerDay:   map[string]int{},
+			PerWeek:  map[string]int{},
+			JobTypes: map[string]int{},
+			Versions: map[string]int{},
+			Aspects:  map[string]int{},
+		},
+		terms: map[string]int{},
+	}
+}
+
+// Collect consumes all jobs from the channel until it is closed, and returns
+// the resulting statistics.
+func Collect(jobs <-chan *midjourney.Job) *Stats {
+	c := NewCollector()
+	for j := range jobs {
+		c.Add(j)
+	}
+
+	return c.Stats()
+}
+
+// Add adds the given jobs to the statistics. Jobs which have already been
+// added are ignored.
+func (c *Collector) Add(jobs ...*midjourney.Job) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	for _, j := range jobs {
+		if j == nil || (j.ID != "" && c.seen[j.ID]) {
+			continue
+		}
+		if j.ID != "" {
+			c.seen[j.ID] = true
+		}
+
+		c.add(j)
+	}
+}
+
+func (c *Collector) add(j *midjourney.Job) {
+	s := c.stats
+	s.Total++
+
+	if !j.EnqueueTime.IsZero() {
+		t := j.EnqueueTime.UTC()
+		s.PerDay[t.Format("2006-01-02")]++
+		year, week := t.ISOWeek()
+		s.PerWeek[fmt.Sprintf("%04d-W%02d", year, week)]++
+	}
+
+	jobType := string(j.Type)
+	if jobType == "" {
+		jobType = "unknown"
+	}
+	s.JobTypes[jobType]++
+	if j.Type == midjourney.JobTypeGrid {
+		s.Grids++
+	} else if j.Type == midjourney.JobTypeUpscale {
+		s.Upscales++
+	}
+
+	version := "default"
+	aspect := "1:1"
+	if p := j.ParsedParams; p != nil {
+		if p.Version != "" {
+			version = string(p.Version)
+		}
+		if p.Aspect != "" {
+			aspect = p.Aspect
+		}
+		if p.Fast {
+			s.FastParam++
+		}
+	}
+	s.Versions[version]++
+	s.Aspects[aspect]++
+
+	if j.LowPriority {
+		s.Relaxed++
+	} else {
+		s.Fast++
+	}
+	if j.Metered {
+		s.Metered++
+	}
+
+	seen := map[string]bool{}
+	for _, term := range text.Tokenize(j.Prompt) {
+		if seen[term] || !countableTerm(term) {
+			continue
+		}
+		seen[term] = true
+		c.terms[term]++
+	}
+}
+
+// Stats returns a snapshot of the statistics collected so far.
+func (c *Collector) Stats() *Stats {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	s := *c.stats
+	s.PerDay = copyMap(s.PerDay)
+	s.PerWeek = copyMap(s.PerWeek)
+	s.JobTypes = copyMap(s.JobTypes)
+	s.Versions = copyMap(s.Versions)
+	s.Aspects = copyMap(s.Aspects)
+
+	if s.Grids > 0 {
+		s.UpscaleRatio = float64(s.Upscales) / float64(s.Grids)
+	}
+
+	n := c.TopTerms
+	if n <= 0 {
+		n = DefaultTopTerms
+	}
+	s.TopTerms = topTerms(c.terms, n)
+
+	return &s
+}
+
+func copyMap(m map[string]int) map[string]int {
+	c := make(map[string]int, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
+
+func topTerms(terms map[string]int, n int) []*TermCount {
+	counts := make([]*TermCount, 0, len(terms))
+	for term, count := range terms {
+		counts = append(counts, &TermCount{Term: term, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+
+		return counts[i].Term < counts[j].Term
+	})
+
+	if len(counts) > n {
+		counts = counts[:n]
+	}
+
+	return counts
+}
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true,
+	"into": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+	"the": true, "to": true, "with": true, "without": true,
+}
+
+func countableTerm(term string) bool {
+	if len(term) < 2 || stopWords[term] {
+		return false
+	}
+	if _, err := strconv.Atoi(term); err == nil {
+		return false
+	}
+
+	return true
+}
+
+// WriteJSON writes the statistics as indented JSON to w.
+func (s *Stats) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(s)
+}
+
+// WriteCSV writes the statistics to w as CSV with "metric", "key" and "value"
+// columns. Scalar metrics have an empty key.
+func (s *Stats) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	rows := [][]string{
+		{"metric", "key", "value"},
+		{"total", "", strconv.Itoa(s.Total)},
+		{"grids", "", strconv.Itoa(s.Grids)},
+		{"upscales", "", strconv.Itoa(s.Upscales)},
+		{"upscale_ratio", "", strconv.FormatFloat(s.UpscaleRatio, 'f', 4, 64)},
+		{"fast", "", strconv.Itoa(s.Fast)},
+		{"relaxed", "", strconv.Itoa(s.Relaxed)},
+		{"metered", "", strconv.Itoa(s.Metered)},
+		{"fast_param", "", strconv.Itoa(s.FastParam)},
+	}
+
+	maps := []struct {
+		name string
+		m    map[string]int
+	}{
+		{"per_day", s.PerDay},
+		{"per_week", s.PerWeek},
+		{"job_types", s.JobTypes},
+		{"versions", s.Versions},
+		{"aspects", s.Aspects},
+	}
+	for _, m := range maps {
+		keys := make([]string, 0, len(m.m))
+		for k := range m.m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			rows = append(rows, []string{m.name, k, strconv.Itoa(m.m[k])})
+		}
+	}
+
+	for _, tc := range s.TopTerms {
+		rows = append(rows, []string{
+			"top_terms", tc.Term, strconv.Itoa(tc.Count),
+		})
+	}
+
+	err := cw.WriteAll(rows)
+	if err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
@@ -0,0 +1,135 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-midjourney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(d int) midjourney.Time {
+	return midjourney.Time{Time: time.Date(2022, 12, d, 12, 0, 0, 0, time.UTC)}
+}
+
+func testJobs() []*midjourney.Job {
+	return []*midjourney.Job{
+		{
+			ID:          "1",
+			Type:        midjourney.JobTypeGrid,
+			Prompt:      "a red cat on the moon",
+			EnqueueTime: at(10),
+			ParsedParams: &midjourney.ParsedJobParams{
+				Aspect: "16:9", Version: "4", Fast: true,
+			},
+		},
+		{
+			ID:          "2",
+			Type:        midjourney.JobTypeUpscale,
+			Prompt:      "a red cat, 4k",
+			EnqueueTime: at(11),
+			LowPriority: true,
+		},
+		{
+			ID:          "3",
+			Type:        midjourney.JobTypeUpscale,
+			Prompt:      "blue dog",
+			EnqueueTime: at(12),
+			Metered:     true,
+			ParsedParams: &midjourney.ParsedJobParams{
+				Version: "4",
+			},
+		},
+		{
+			ID:          "4",
+			Type:        midjourney.JobTypeGrid,
+			Prompt:      "cat cat cat",
+			EnqueueTime: at(12),
+			LowPriority: true,
+		},
+	}
+}
+
+func TestCollector(t *testing.T) {
+	c := NewCollector()
+	c.TopTerms = 3
+	c.Add(testJobs()...)
+	c.Add(testJobs()[0]) // duplicates are ignored
+
+	got := c.Stats()
+
+	assert.Equal(t, &Stats{
+		Total: 4,
+		PerDay: map[string]int{
+			"2022-12-10": 1, "2022-12-11": 1, "2022-12-12": 2,
+		},
+		PerWeek:      map[string]int{"2022-W49": 2, "2022-W50": 2},
+		JobTypes:     map[string]int{"grid": 2, "upscale": 2},
+		Versions:     map[string]int{"4": 2, "default": 2},
+		Aspects:      map[string]int{"16:9": 1, "1:1": 3},
+		Fast:         2,
+		Relaxed:      2,
+		Metered:      1,
+		FastParam:    1,
+		Grids:        2,
+		Upscales:     2,
+		UpscaleRatio: 1,
+		TopTerms: []*TermCount{
+			{Term: "cat", Count: 3},
+			{Term: "red", Count: 2},
+			{Term: "4k", Count: 1},
+		},
+	}, got)
+}
+
+func TestCollect(t *testing.T) {
+	ch := make(chan *midjourney.Job)
+	go func() {
+		for _, j := range testJobs() {
+			ch <- j
+		}
+		close(ch)
+	}()
+
+	got := Collect(ch)
+
+	assert.Equal(t, 4, got.Total)
+}
+
+func TestStats_WriteJSON(t *testing.T) {
+	c := NewCollector()
+	c.Add(testJobs()...)
+
+	var buf bytes.Buffer
+	err := c.Stats().WriteJSON(&buf)
+	require.NoError(t, err)
+
+	var got map[string]any
+	err = json.Unmarshal(buf.Bytes(), &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(4), got["total"])
+	assert.Equal(t, float64(1), got["upscale_ratio"])
+}
+
+func TestStats_WriteCSV(t *testing.T) {
+	c := NewCollector()
+	c.TopTerms = 1
+	c.Add(testJobs()...)
+
+	var buf bytes.Buffer
+	err := c.Stats().WriteCSV(&buf)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, "metric,key,value", lines[0])
+	assert.Contains(t, lines, "total,,4")
+	assert.Contains(t, lines, "upscale_ratio,,1.0000")
+	assert.Contains(t, lines, "per_day,2022-12-12,2")
+	assert.Contains(t, lines, "aspects,16:9,1")
+	assert.Equal(t, "top_terms,cat,3", lines[len(lines)-1])
+}
@@ -0,0 +1,170 @@
+package midjourney
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSubmitModeDisabled = fmt.Errorf(
+		"%w: submit mode disabled, see WithSubmitMode", Err,
+	)
+	ErrPromptRequired  = fmt.Errorf("%w: prompt required", Err)
+	ErrInvalidImageNum = fmt.Errorf("%w: invalid image number", Err)
+	ErrInvalidSubmit   = fmt.Errorf("%w: invalid submit action", Err)
+	ErrSubmitFailed    = fmt.Errorf("%w: job submission failed", Err)
+	ErrJobFailed       = fmt.Errorf("%w: job failed", Err)
+)
+
+type SubmitAction string
+
+const (
+	SubmitImagine   SubmitAction = "imagine"
+	SubmitVariation SubmitAction = "variation"
+	SubmitUpscale   SubmitAction = "upscale"
+)
+
+// SubmitRequest describes a job to enqueue.
+type SubmitRequest struct {
+	Action SubmitAction `json:"action"`
+
+	// Prompt is the full prompt of imagine jobs, including any parameters.
+	Prompt string `json:"prompt,omitempty"`
+
+	// ReferenceJobID and ReferenceImageNum identify the image within a grid job
+	// which variation and upscale jobs are derived from. Image numbers start at
+	// 1.
+	ReferenceJobID    string `json:"reference_job_id,omitempty"`
+	ReferenceImageNum int    `json:"reference_image_num,omitempty"`
+}
+
+func (r *SubmitRequest) validate() error {
+	switch r.Action {
+	case SubmitImagine:
+		if strings.TrimSpace(r.Prompt) == "" {
+			return ErrPromptRequired
+		}
+	case SubmitVariation, SubmitUpscale:
+		if r.ReferenceJobID == "" {
+			return ErrJobIDRequired
+		}
+		if r.ReferenceImageNum < 1 {
+			return fmt.Errorf(
+				"%w: %d", ErrInvalidImageNum, r.ReferenceImageNum,
+			)
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidSubmit, r.Action)
+	}
+
+	return nil
+}
+
+type submitResponse struct {
+	Job   *Job   `json:"job,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Submission is a job which has been enqueued, and can be awaited.
+type Submission struct {
+	// Job is the pending job as returned when it was enqueued.
+	Job *Job
+
+	// PollInterval is the time between checks of the job's status in Wait.
+	// Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+
+	client *Client
+}
+
+// Submit enqueues a new job. It requires the client to be in submit mode, as
+// enabled with the WithSubmitMode option.
+func (c *Client) Submit(
+	ctx context.Context,
+	req *SubmitRequest,
+) (*Submission, error) {
+	if !c.API.SubmitMode {
+		return nil, ErrSubmitModeDisabled
+	}
+
+	err := req.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &submitResponse{}
+	err = c.API.Post(ctx, "app/submit-job/", nil, req, resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%w: %s", ErrSubmitFailed, resp.Error)
+	}
+	if resp.Job == nil || resp.Job.ID == "" {
+		return nil, fmt.Errorf("%w: no job returned", ErrSubmitFailed)
+	}
+
+	return &Submission{Job: resp.Job, client: c}, nil
+}
+
+// Imagine enqueues a new grid job for the given prompt.
+func (c *Client) Imagine(
+	ctx context.Context,
+	prompt string,
+) (*Submission, error) {
+	return c.Submit(ctx, &SubmitRequest{
+		Action: SubmitImagine,
+		Prompt: prompt,
+	})
+}
+
+// Variation enqueues variations of the given image of a grid job.
+func (c *Client) Variation(
+	ctx context.Context,
+	jobID string,
+	imageNum int,
+) (*Submission, error) {
+	return c.Submit(ctx, &SubmitRequest{
+		Action:            SubmitVariation,
+		ReferenceJobID:    jobID,
+		ReferenceImageNum: imageNum,
+	})
+}
+
+// Upscale enqueues an upscale of the given image of a grid job.
+func (c *Client) Upscale(
+	ctx context.Context,
+	jobID string,
+	imageNum int,
+) (*Submission, error) {
+	return c.Submit(ctx, &SubmitRequest{
+		Action:            SubmitUpscale,
+		ReferenceJobID:    jobID,
+		ReferenceImageNum: imageNum,
+	})
+}
+
+// Wait polls the job's status until it is completed, and returns the completed
+// job. If progress is not nil, it is called with the job after every poll. See
+// Client.WaitJob for details.
+func (s *Submission) Wait(
+	ctx context.Context,
+	progress func(*Job),
+) (*Job, error) {
+	j, err := s.client.WaitJob(ctx, s.Job.ID, &WaitOptions{
+		Interval: s.PollInterval,
+		Progress: func(j *Job) {
+			s.Job = j
+			if progress != nil {
+				progress(j)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
@@ -0,0 +1,207 @@
+package midjourney
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSubmitServer implements app/submit-job/ and app/job-status/. Each job
+// moves through the given statuses, one per status request.
+type fakeSubmitServer struct {
+	*fakeServer
+	statuses []JobStatus
+	requests []*SubmitRequest
+	status   *fakeJobStatus
+}
+
+func newFakeSubmitServer(statuses ...JobStatus) *fakeSubmitServer {
+	s := &fakeSubmitServer{
+		fakeServer: newFakeServer(),
+		statuses:   statuses,
+		status:     newFakeJobStatus(),
+	}
+	s.handle("/app/submit-job/", s.submitJob)
+	s.handle("/app/job-status/", s.status.ServeHTTP)
+
+	return s
+}
+
+func (s *fakeSubmitServer) submitJob(w http.ResponseWriter, r *http.Request) {
+	req := &SubmitRequest{}
+	_ = json.NewDecoder(r.Body).Decode(req)
+	s.requests = append(s.requests, req)
+
+	if req.Prompt == "banned" {
+		_, _ = w.Write([]byte(`{"error":"banned prompt"}`))
+
+		return
+	}
+
+	j := &Job{
+		ID:             fmt.Sprintf("job-%d", len(s.requests)),
+		CurrentStatus:  JobStatusQueued,
+		Prompt:         req.Prompt,
+		ReferenceJobID: req.ReferenceJobID,
+	}
+	states := []*Job{}
+	for _, status := range s.statuses {
+		state := *j
+		state.CurrentStatus = status
+		states = append(states, &state)
+	}
+	s.status.set(j.ID, states...)
+
+	_ = json.NewEncoder(w).Encode(&submitResponse{Job: j})
+}
+
+func TestClient_Submit_SubmitModeDisabled(t *testing.T) {
+	srv := newFakeSubmitServer()
+	c := newTestClient(t, srv)
+
+	_, err := c.Imagine(context.Background(), "a cat")
+
+	assert.ErrorIs(t, err, ErrSubmitModeDisabled)
+	assert.Empty(t, srv.requests)
+}
+
+func TestClient_Submit(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeSubmitServer()
+	c := newTestSubmitClient(t, srv)
+
+	s, err := c.Imagine(ctx, "a cat --ar 3:2")
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", s.Job.ID)
+	assert.Equal(t, JobStatusQueued, s.Job.CurrentStatus)
+
+	_, err = c.Variation(ctx, "job-1", 2)
+	require.NoError(t, err)
+	_, err = c.Upscale(ctx, "job-1", 4)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*SubmitRequest{
+		{Action: SubmitImagine, Prompt: "a cat --ar 3:2"},
+		{
+			Action:            SubmitVariation,
+			ReferenceJobID:    "job-1",
+			ReferenceImageNum: 2,
+		},
+		{
+			Action:            SubmitUpscale,
+			ReferenceJobID:    "job-1",
+			ReferenceImageNum: 4,
+		},
+	}, srv.requests)
+
+	_, err = c.Imagine(ctx, "banned")
+	assert.ErrorIs(t, err, ErrSubmitFailed)
+	assert.Contains(t, err.Error(), "banned prompt")
+}
+
+func TestClient_Submit_Validation(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeSubmitServer()
+	c := newTestSubmitClient(t, srv)
+
+	tests := []struct {
+		name    string
+		req     *SubmitRequest
+		wantErr error
+	}{
+		{
+			name:    "empty prompt",
+			req:     &SubmitRequest{Action: SubmitImagine, Prompt: " "},
+			wantErr: ErrPromptRequired,
+		},
+		{
+			name:    "no reference job",
+			req:     &SubmitRequest{Action: SubmitUpscale},
+			wantErr: ErrJobIDRequired,
+		},
+		{
+			name: "no image number",
+			req: &SubmitRequest{
+				Action: SubmitVariation, ReferenceJobID: "a",
+			},
+			wantErr: ErrInvalidImageNum,
+		},
+		{
+			name:    "unknown action",
+			req:     &SubmitRequest{Action: "blend"},
+			wantErr: ErrInvalidSubmit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Submit(ctx, tt.req)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+	assert.Empty(t, srv.requests)
+}
+
+func TestSubmission_Wait(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeSubmitServer(
+		JobStatusQueued, JobStatusRunning, JobStatusCompleted,
+	)
+	c := newTestSubmitClient(t, srv)
+
+	s, err := c.Imagine(ctx, "a cat")
+	require.NoError(t, err)
+	s.PollInterval = time.Millisecond
+
+	seen := []JobStatus{}
+	j, err := s.Wait(ctx, func(j *Job) {
+		seen = append(seen, j.CurrentStatus)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, JobStatusCompleted, j.CurrentStatus)
+	assert.Equal(t, []JobStatus{
+		JobStatusQueued, JobStatusRunning, JobStatusCompleted,
+	}, seen)
+	assert.Equal(t, j, s.Job)
+}
+
+func TestSubmission_Wait_Failed(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeSubmitServer(
+		JobStatusRunning, JobStatusFailed,
+	)
+	c := newTestSubmitClient(t, srv)
+
+	s, err := c.Imagine(ctx, "a cat")
+	require.NoError(t, err)
+	s.PollInterval = time.Millisecond
+
+	_, err = s.Wait(ctx, nil)
+
+	assert.ErrorIs(t, err, ErrJobFailed)
+}
+
+func TestSubmission_Wait_Canceled(t *testing.T) {
+	srv := newFakeSubmitServer(JobStatusRunning)
+	c := newTestSubmitClient(t, srv)
+
+	s, err := c.Imagine(context.Background(), "a cat")
+	require.NoError(t, err)
+	s.PollInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), 20*time.Millisecond,
+	)
+	defer cancel()
+
+	_, err = s.Wait(ctx, nil)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
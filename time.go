@@ -1,28 +1,101 @@
 package midjourney
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const TimeFormat = "2006-01-02 15:04:05.999999"
 
+// TimeLayouts are the layouts Time attempts to parse values with, in order.
+// Fractional seconds of any precision are accepted by all layouts, and values
+// without a time zone are assumed to be in UTC.
+var TimeLayouts = []string{
+	TimeFormat,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05Z07",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05Z07",
+	time.RFC1123Z,
+	time.RFC1123,
+	"2006-01-02",
+}
+
 type Time struct {
 	time.Time
 }
 
+// UnmarshalJSON parses a JSON string using any of TimeLayouts, or a JSON number
+// as a Unix timestamp in seconds or milliseconds. Null and empty strings result
+// in a zero time. Unquoted strings are also accepted.
 func (ct *Time) UnmarshalJSON(b []byte) (err error) {
-	s := strings.Trim(string(b), "\"")
-	if s == "null" {
+	b = bytes.TrimSpace(b)
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		err = json.Unmarshal(b, &s)
+		if err != nil {
+			return fmt.Errorf("%w: time: %s", ErrInvalidValue, err)
+		}
+	}
+
+	s = strings.TrimSpace(s)
+	if s == "" || s == "null" {
 		ct.Time = time.Time{}
 
 		return
 	}
 
-	ct.Time, err = time.Parse(TimeFormat, s)
+	if n, perr := strconv.ParseFloat(s, 64); perr == nil {
+		ct.Time, err = unixTime(n)
+
+		return
+	}
+
+	for _, layout := range TimeLayouts {
+		t, perr := time.Parse(layout, s)
+		if perr == nil {
+			ct.Time = t
+
+			return
+		}
+	}
+
+	return fmt.Errorf("%w: time: %q", ErrInvalidValue, s)
+}
+
+// unixTimeMillisThreshold is the value above which Unix timestamps are assumed
+// to be in milliseconds, which is sometime in the year 5138 in seconds.
+const unixTimeMillisThreshold = 1e11
+
+// unixTime converts a Unix timestamp in seconds or milliseconds to a time.
+// Times outside of the years 0 to 9999 are rejected, as they cannot be
+// formatted with TimeFormat and parsed again.
+func unixTime(n float64) (time.Time, error) {
+	if math.IsNaN(n) || math.IsInf(n, 0) || math.Abs(n) > 1e15 {
+		return time.Time{}, fmt.Errorf("%w: time: %v", ErrInvalidValue, n)
+	}
+
+	var t time.Time
+	if math.Abs(n) >= unixTimeMillisThreshold {
+		t = time.UnixMilli(int64(n)).UTC()
+	} else {
+		sec, frac := math.Modf(n)
+		t = time.Unix(int64(sec), int64(frac*1e9)).UTC()
+	}
+
+	if t.Year() < 0 || t.Year() > 9999 {
+		return time.Time{}, fmt.Errorf(
+			"%w: time: %v is out of range", ErrInvalidValue, n,
+		)
+	}
 
-	return
+	return t, nil
 }
 
 func (ct *Time) MarshalJSON() ([]byte, error) {
@@ -30,5 +103,7 @@ func (ct *Time) MarshalJSON() ([]byte, error) {
 		return []byte("null"), nil
 	}
 
-	return []byte(fmt.Sprintf("\"%s\"", ct.Time.Format(TimeFormat))), nil
+	return []byte(
+		fmt.Sprintf("\"%s\"", ct.Time.UTC().Format(TimeFormat)),
+	), nil
 }
@@ -0,0 +1,134 @@
+package midjourney
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	want := time.Date(2022, 9, 7, 6, 58, 2, 200753000, time.UTC)
+	tests := []struct {
+		name    string
+		json    string
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name: "time format",
+			json: `"2022-09-07 06:58:02.200753"`,
+			want: want,
+		},
+		{
+			name: "unquoted",
+			json: `2022-09-07 06:58:02.200753`,
+			want: want,
+		},
+		{
+			name: "nanoseconds",
+			json: `"2022-09-07 06:58:02.200753000"`,
+			want: want,
+		},
+		{
+			name: "no fractional seconds",
+			json: `"2022-09-07 06:58:02"`,
+			want: want.Truncate(time.Second),
+		},
+		{
+			name: "short zone",
+			json: `"2022-09-07 08:58:02.200753+02"`,
+			want: want,
+		},
+		{
+			name: "zone",
+			json: `"2022-09-07 01:58:02.200753-05:00"`,
+			want: want,
+		},
+		{
+			name: "RFC3339",
+			json: `"2022-09-07T06:58:02.200753Z"`,
+			want: want,
+		},
+		{
+			name: "RFC3339 with offset",
+			json: `"2022-09-07T07:58:02.200753+01:00"`,
+			want: want,
+		},
+		{
+			name: "ISO without zone",
+			json: `"2022-09-07T06:58:02.200753"`,
+			want: want,
+		},
+		{
+			name: "RFC1123",
+			json: `"Wed, 07 Sep 2022 06:58:02 GMT"`,
+			want: want.Truncate(time.Second),
+		},
+		{
+			name: "date",
+			json: `"2022-09-07"`,
+			want: time.Date(2022, 9, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "unix seconds",
+			json: `1662533882.200753`,
+			want: want.Truncate(time.Microsecond),
+		},
+		{
+			name: "unix milliseconds",
+			json: `1662533882200`,
+			want: want.Truncate(time.Millisecond),
+		},
+		{name: "null", json: `null`},
+		{name: "empty", json: `""`},
+		{name: "invalid", json: `"yesterday"`, wantErr: ErrInvalidValue},
+		{name: "NaN", json: `"NaN"`, wantErr: ErrInvalidValue},
+		{
+			name:    "unix milliseconds after year 9999",
+			json:    `999999999999999`,
+			wantErr: ErrInvalidValue,
+		},
+		{
+			name:    "unix seconds before year 0",
+			json:    `-99999999999`,
+			wantErr: ErrInvalidValue,
+		},
+		{
+			name: "unix milliseconds in year 9999",
+			json: `253402300799999`,
+			want: time.Date(9999, 12, 31, 23, 59, 59, 999000000, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &Time{Time: time.Now()}
+			err := got.UnmarshalJSON([]byte(tt.json))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.WithinDuration(t, tt.want, got.Time, time.Microsecond)
+			if tt.want.IsZero() {
+				assert.True(t, got.IsZero())
+			}
+		})
+	}
+}
+
+func TestTime_MarshalJSON(t *testing.T) {
+	loc := time.FixedZone("X", 2*60*60)
+	ct := &Time{Time: time.Date(2022, 9, 7, 8, 58, 2, 200753000, loc)}
+
+	b, err := ct.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"2022-09-07 06:58:02.200753"`, string(b))
+
+	b, err = (&Time{}).MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+}
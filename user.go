@@ -0,0 +1,127 @@
+package midjourney
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+var ErrUserNotFound = fmt.Errorf("%w: user", ErrNotFound)
+
+// User is a public user profile.
+type User struct {
+	ID          string `json:"id,omitempty"`
+	Username    string `json:"username,omitempty"`
+	AvatarJobID string `json:"avatar_job_id,omitempty"`
+	CoverJobID  string `json:"cover_job_id,omitempty"`
+}
+
+// Account is the profile and subscription details of the authenticated user.
+type Account struct {
+	User
+
+	Subscription *Subscription `json:"subscription,omitempty"`
+}
+
+type Subscription struct {
+	Plan        string `json:"plan,omitempty"`
+	Status      string `json:"status,omitempty"`
+	RenewalDate *Time  `json:"renewal_date,omitempty"`
+
+	// FastGPUMinutes is the number of fast GPU minutes included in the plan
+	// per billing period.
+	FastGPUMinutes float64 `json:"fast_gpu_minutes,omitempty"`
+
+	FastGPUMinutesUsed    float64 `json:"fast_gpu_minutes_used,omitempty"`
+	RelaxedGPUMinutesUsed float64 `json:"relaxed_gpu_minutes_used,omitempty"`
+}
+
+// FastGPUMinutesRemaining returns the number of fast GPU minutes left in the
+// current billing period.
+func (s *Subscription) FastGPUMinutesRemaining() float64 {
+	if s.FastGPUMinutesUsed >= s.FastGPUMinutes {
+		return 0
+	}
+
+	return s.FastGPUMinutes - s.FastGPUMinutesUsed
+}
+
+// Me returns the account of the authenticated user.
+func (c *Client) Me(ctx context.Context) (*Account, error) {
+	if c.API.AuthToken == "" {
+		return nil, ErrNoAuthToken
+	}
+
+	acc := &Account{}
+	err := c.API.Get(ctx, "app/me/", nil, acc)
+	if err != nil {
+		return nil, err
+	}
+	if acc.ID == "" {
+		return nil, ErrUserNotFound
+	}
+
+	c.setCurrentUserID(c.API.AuthToken, acc.ID)
+
+	return acc, nil
+}
+
+// User returns the public profile of the given user.
+func (c *Client) User(ctx context.Context, userID string) (*User, error) {
+	if userID == "" {
+		return nil, ErrUserIDRequired
+	}
+
+	var u *User
+	err := c.API.Get(ctx, "app/user/", url.Values{
+		"user_id": []string{userID},
+	}, &u)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || u.ID == "" {
+		return nil, fmt.Errorf("%w: id=%s", ErrUserNotFound, userID)
+	}
+
+	return u, nil
+}
+
+// userIDOrMe returns userID if not empty, or the ID of the authenticated user.
+func (c *Client) userIDOrMe(
+	ctx context.Context,
+	userID string,
+) (string, error) {
+	if userID != "" {
+		return userID, nil
+	}
+
+	if id := c.currentUserID(c.API.AuthToken); id != "" {
+		return id, nil
+	}
+
+	acc, err := c.Me(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return acc.ID, nil
+}
+
+func (c *Client) currentUserID(authToken string) string {
+	c.me.Lock()
+	defer c.me.Unlock()
+
+	if c.me.authToken != authToken {
+		return ""
+	}
+
+	return c.me.userID
+}
+
+func (c *Client) setCurrentUserID(authToken, userID string) {
+	c.me.Lock()
+	defer c.me.Unlock()
+
+	c.me.authToken = authToken
+	c.me.userID = userID
+}
@@ -0,0 +1,140 @@
+package midjourney
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUserServer implements app/me/, app/user/ and app/recent-jobs, recording
+// the user IDs jobs were requested for.
+type fakeUserServer struct {
+	*fakeServer
+	meCalls int
+	queries []*RecentJobsQuery
+}
+
+func newFakeUserServer() *fakeUserServer {
+	s := &fakeUserServer{fakeServer: newFakeServer()}
+	s.handle("/app/me/", func(w http.ResponseWriter, _ *http.Request) {
+		s.meCalls++
+		_, _ = w.Write([]byte(`{
+			"id": "u1",
+			"username": "alice",
+			"avatar_job_id": "j1",
+			"subscription": {
+				"plan": "standard",
+				"status": "active",
+				"renewal_date": "2023-01-01T00:00:00Z",
+				"fast_gpu_minutes": 900,
+				"fast_gpu_minutes_used": 120.5,
+				"relaxed_gpu_minutes_used": 30
+			}
+		}`))
+	})
+	s.handle("/app/user/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("user_id") != "u2" {
+			_, _ = w.Write([]byte(`null`))
+
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"id": "u2",
+			"username": "bob",
+			"avatar_job_id": "j2",
+			"cover_job_id": "j3"
+		}`))
+	})
+	s.handle("/app/recent-jobs", recentJobsRoute(
+		func(q *RecentJobsQuery) []*Job {
+			s.queries = append(s.queries, q)
+
+			return []*Job{}
+		},
+	))
+
+	return s
+}
+
+func TestClient_Me(t *testing.T) {
+	c := newTestClient(t, newFakeUserServer())
+
+	acc, err := c.Me(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "u1", acc.ID)
+	assert.Equal(t, "alice", acc.Username)
+	assert.Equal(t, "j1", acc.AvatarJobID)
+	require.NotNil(t, acc.Subscription)
+	assert.Equal(t, "standard", acc.Subscription.Plan)
+	assert.Equal(t, 2023, acc.Subscription.RenewalDate.Year())
+	assert.InDelta(t, 779.5, acc.Subscription.FastGPUMinutesRemaining(), 0)
+	assert.InDelta(t, 30.0, acc.Subscription.RelaxedGPUMinutesUsed, 0)
+}
+
+func TestClient_Me_NoAuthToken(t *testing.T) {
+	c, err := New()
+	require.NoError(t, err)
+
+	_, err = c.Me(context.Background())
+
+	assert.ErrorIs(t, err, ErrNoAuthToken)
+}
+
+func TestSubscription_FastGPUMinutesRemaining(t *testing.T) {
+	s := &Subscription{FastGPUMinutes: 60, FastGPUMinutesUsed: 75}
+
+	assert.InDelta(t, 0.0, s.FastGPUMinutesRemaining(), 0)
+}
+
+func TestClient_User(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, newFakeUserServer())
+
+	u, err := c.User(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, &User{
+		ID: "u2", Username: "bob", AvatarJobID: "j2", CoverJobID: "j3",
+	}, u)
+
+	_, err = c.User(ctx, "u3")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = c.User(ctx, "")
+	assert.ErrorIs(t, err, ErrUserIDRequired)
+}
+
+func TestClient_DefaultsToCurrentUser(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeUserServer()
+	c := newTestClient(t, srv)
+
+	_, err := c.Home(ctx, "")
+	require.NoError(t, err)
+	_, err = c.Bookmarks(ctx, "")
+	require.NoError(t, err)
+	_, err = c.Home(ctx, "u2")
+	require.NoError(t, err)
+
+	it := c.BookmarksIter("")
+	assert.False(t, it.Next(ctx))
+	require.NoError(t, it.Err())
+
+	require.Len(t, srv.queries, 4)
+	assert.Equal(t, "u1", srv.queries[0].UserID)
+	assert.Equal(t, "u1", srv.queries[1].UserIDLiked)
+	assert.Equal(t, "u2", srv.queries[2].UserID)
+	assert.Equal(t, "u1", srv.queries[3].UserIDLiked)
+	assert.Equal(t, 1, srv.meCalls)
+
+	// Changing the auth token invalidates the cached user ID.
+	err = c.Set(WithAuthToken("other"))
+	require.NoError(t, err)
+	_, err = c.Home(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, srv.meCalls)
+}
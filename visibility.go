@@ -0,0 +1,126 @@
+package midjourney
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var ErrModHidden = fmt.Errorf("%w: job hidden by moderators", Err)
+
+// ModHiddenError is returned when a change to a job is refused, or a job being
+// waited on stops, because the job has been hidden by moderators.
+type ModHiddenError struct {
+	JobID string
+
+	// Err is the error returned by the refused request, if any.
+	Err error
+}
+
+func (e *ModHiddenError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%s: id=%s", ErrModHidden, e.JobID)
+	}
+
+	return fmt.Sprintf("%s: id=%s: %s", ErrModHidden, e.JobID, e.Err)
+}
+
+func (e *ModHiddenError) Is(target error) bool {
+	return errors.Is(ErrModHidden, target)
+}
+
+func (e *ModHiddenError) Unwrap() error {
+	return e.Err
+}
+
+type jobVisibilityRequest struct {
+	JobID string `json:"jobId"`
+	Value bool   `json:"value"`
+}
+
+// HideJob hides the given job from the current user's public profile.
+func (c *Client) HideJob(ctx context.Context, jobID string) error {
+	return c.setJobVisibility(ctx, "app/hide-job/", jobID, true)
+}
+
+// UnhideJob reverses HideJob.
+func (c *Client) UnhideJob(ctx context.Context, jobID string) error {
+	return c.setJobVisibility(ctx, "app/hide-job/", jobID, false)
+}
+
+// PublishJob publishes the given job to the community feed.
+func (c *Client) PublishJob(ctx context.Context, jobID string) error {
+	return c.setJobVisibility(ctx, "app/publish-job/", jobID, true)
+}
+
+// HideJobs hides all given jobs, with at most concurrency requests in flight
+// at once. When concurrency is less than one, DefaultConcurrency is used. If
+// any job fails to be hidden, a *BulkError is returned.
+func (c *Client) HideJobs(
+	ctx context.Context,
+	jobIDs []string,
+	concurrency int,
+) error {
+	return c.bulkJobs(ctx, jobIDs, concurrency, c.HideJob)
+}
+
+// UnhideJobs unhides all given jobs, like HideJobs.
+func (c *Client) UnhideJobs(
+	ctx context.Context,
+	jobIDs []string,
+	concurrency int,
+) error {
+	return c.bulkJobs(ctx, jobIDs, concurrency, c.UnhideJob)
+}
+
+// PublishJobs publishes all given jobs, like HideJobs.
+func (c *Client) PublishJobs(
+	ctx context.Context,
+	jobIDs []string,
+	concurrency int,
+) error {
+	return c.bulkJobs(ctx, jobIDs, concurrency, c.PublishJob)
+}
+
+// setJobVisibility performs a visibility change. When the change is refused,
+// the job is fetched to check if it has been hidden by moderators, in which
+// case a *ModHiddenError is returned.
+func (c *Client) setJobVisibility(
+	ctx context.Context,
+	path string,
+	jobID string,
+	value bool,
+) error {
+	if jobID == "" {
+		return ErrJobIDRequired
+	}
+
+	err := c.action(
+		ctx, http.MethodPost, path, nil,
+		&jobVisibilityRequest{JobID: jobID, Value: value},
+	)
+	if err == nil || !errors.Is(err, ErrResponse) {
+		return err
+	}
+
+	j, jobErr := c.GetJob(ctx, jobID)
+	if jobErr == nil && j.ModHidden {
+		return &ModHiddenError{JobID: jobID, Err: err}
+	}
+
+	return err
+}
+
+func (c *Client) bulkJobs(
+	ctx context.Context,
+	jobIDs []string,
+	concurrency int,
+	fn func(ctx context.Context, jobID string) error,
+) error {
+	if len(jobIDs) == 0 {
+		return ErrJobIDsRequired
+	}
+
+	return runBulk(ctx, jobIDs, concurrency, fn)
+}
@@ -0,0 +1,136 @@
+package midjourney
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVisibilityServer implements app/hide-job/, app/publish-job/ and
+// app/job-status/. Changes to jobs hidden by moderators, or unknown jobs, are
+// refused.
+type fakeVisibilityServer struct {
+	*fakeServer
+	status *fakeJobStatus
+}
+
+func newFakeVisibilityServer() *fakeVisibilityServer {
+	s := &fakeVisibilityServer{
+		fakeServer: newFakeServer(),
+		status: newFakeJobStatus(
+			&Job{ID: "a"},
+			&Job{ID: "b"},
+			&Job{ID: "mod", ModHidden: true},
+		),
+	}
+	s.handle("/app/hide-job/", s.setVisibility(func(j *Job, v bool) {
+		j.Hidden = v
+	}))
+	s.handle("/app/publish-job/", s.setVisibility(func(j *Job, v bool) {
+		j.IsPublished = v
+	}))
+	s.handle("/app/job-status/", s.status.ServeHTTP)
+
+	return s
+}
+
+func (s *fakeVisibilityServer) setVisibility(
+	set func(j *Job, value bool),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req jobVisibilityRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		j := s.status.job(req.JobID)
+		if j == nil || j.ModHidden {
+			_, _ = w.Write([]byte(`{"error":"not allowed"}`))
+
+			return
+		}
+
+		set(j, req.Value)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}
+}
+
+func TestClient_HideJob(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeVisibilityServer()
+	c := newTestClient(t, srv)
+
+	require.NoError(t, c.HideJob(ctx, "a"))
+	assert.True(t, srv.status.job("a").Hidden)
+
+	require.NoError(t, c.UnhideJob(ctx, "a"))
+	assert.False(t, srv.status.job("a").Hidden)
+
+	require.NoError(t, c.PublishJob(ctx, "a"))
+	assert.True(t, srv.status.job("a").IsPublished)
+
+	assert.ErrorIs(t, c.HideJob(ctx, ""), ErrJobIDRequired)
+	assert.ErrorIs(t, c.PublishJob(ctx, ""), ErrJobIDRequired)
+}
+
+func TestClient_HideJob_ModHidden(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, newFakeVisibilityServer())
+
+	err := c.PublishJob(ctx, "mod")
+
+	assert.ErrorIs(t, err, ErrModHidden)
+	assert.ErrorIs(t, err, ErrResponse)
+
+	var modErr *ModHiddenError
+	require.ErrorAs(t, err, &modErr)
+	assert.Equal(t, "mod", modErr.JobID)
+
+	var respErr *ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "not allowed", respErr.Message)
+
+	err = c.HideJob(ctx, "unknown")
+	require.ErrorAs(t, err, &respErr)
+	assert.False(t, errors.Is(err, ErrModHidden))
+}
+
+func TestModHiddenError_Is(t *testing.T) {
+	err := error(&ModHiddenError{JobID: "a"})
+
+	assert.ErrorIs(t, err, ErrModHidden)
+	assert.ErrorIs(t, err, Err)
+	assert.False(t, errors.Is(err, ErrResponse))
+	assert.EqualError(t, err, "midjourney: job hidden by moderators: id=a")
+}
+
+func TestClient_HideJobs(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeVisibilityServer()
+	c := newTestClient(t, srv)
+
+	err := c.HideJobs(ctx, []string{"a", "mod", "unknown", "b"}, 2)
+
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, []string{"mod", "unknown"}, bulkErr.IDs())
+	assert.Equal(t, []string{"a", "b"}, bulkErr.Succeeded)
+	assert.ErrorIs(t, err, ErrModHidden)
+	assert.ErrorIs(t, bulkErr.Errors["mod"], ErrModHidden)
+	assert.NotErrorIs(t, bulkErr.Errors["unknown"], ErrModHidden)
+	assert.Contains(t, err.Error(), "2 of 4 failed")
+
+	assert.True(t, srv.status.job("a").Hidden)
+	assert.True(t, srv.status.job("b").Hidden)
+
+	require.NoError(t, c.UnhideJobs(ctx, []string{"a", "b"}, 0))
+	assert.False(t, srv.status.job("a").Hidden)
+
+	require.NoError(t, c.PublishJobs(ctx, []string{"a", "b"}, 1))
+	assert.True(t, srv.status.job("b").IsPublished)
+
+	assert.ErrorIs(t, c.HideJobs(ctx, nil, 0), ErrJobIDsRequired)
+}
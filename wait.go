@@ -0,0 +1,283 @@
+package midjourney
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var ErrJobFlagged = fmt.Errorf("%w: job flagged", Err)
+
+const (
+	// DefaultPollInterval is the initial time between checks of a job's
+	// status while waiting for it to complete.
+	DefaultPollInterval = 5 * time.Second
+
+	// DefaultMaxPollInterval is the longest time between checks of a job's
+	// status while waiting for it to complete.
+	DefaultMaxPollInterval = 30 * time.Second
+
+	// DefaultPollBackoff is the factor the time between checks is multiplied
+	// by after each check which saw no change in status.
+	DefaultPollBackoff = 1.5
+
+	// DefaultPollRetries is the number of consecutive failed checks which are
+	// retried before giving up on all pending jobs.
+	DefaultPollRetries = 3
+
+	// DefaultNotFoundChecks is the number of consecutive checks a job may be
+	// missing from before it fails with ErrJobNotFound.
+	DefaultNotFoundChecks = 3
+)
+
+// WaitOptions controls how WaitJob and WaitJobs poll for job status. The zero
+// value uses the package defaults.
+type WaitOptions struct {
+	// Interval is the time between the first checks, and between checks
+	// following a change in status. Defaults to DefaultPollInterval.
+	Interval time.Duration
+
+	// MaxInterval is the longest time between checks. Defaults to
+	// DefaultMaxPollInterval.
+	MaxInterval time.Duration
+
+	// Backoff is the factor the time between checks is multiplied by when no
+	// status changed. Values below 1 default to DefaultPollBackoff.
+	Backoff float64
+
+	// Retries is the number of consecutive failed checks which are retried,
+	// waiting as if no status changed, before all pending jobs fail with the
+	// error of the last check. Defaults to DefaultPollRetries.
+	Retries int
+
+	// NotFoundChecks is the number of consecutive checks a job may be missing
+	// from before it fails with ErrJobNotFound, as the status of new jobs can
+	// lag behind their submission. Defaults to DefaultNotFoundChecks.
+	NotFoundChecks int
+
+	// Progress is called with the current state of each pending job after
+	// every check.
+	Progress func(*Job)
+}
+
+func (o *WaitOptions) withDefaults() *WaitOptions {
+	opts := &WaitOptions{}
+	if o != nil {
+		*opts = *o
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultPollInterval
+	}
+	if opts.MaxInterval < opts.Interval {
+		opts.MaxInterval = DefaultMaxPollInterval
+		if opts.MaxInterval < opts.Interval {
+			opts.MaxInterval = opts.Interval
+		}
+	}
+	if opts.Backoff < 1 {
+		opts.Backoff = DefaultPollBackoff
+	}
+	if opts.Retries <= 0 {
+		opts.Retries = DefaultPollRetries
+	}
+	if opts.NotFoundChecks <= 0 {
+		opts.NotFoundChecks = DefaultNotFoundChecks
+	}
+
+	return opts
+}
+
+// nextInterval returns the time to wait after a check, given the time waited
+// before it and whether it saw any change in status.
+func (o *WaitOptions) nextInterval(
+	interval time.Duration,
+	changed bool,
+) time.Duration {
+	if changed {
+		return o.Interval
+	}
+
+	interval = time.Duration(float64(interval) * o.Backoff)
+	if interval > o.MaxInterval {
+		return o.MaxInterval
+	}
+
+	return interval
+}
+
+// WaitJob polls the status of the given job until it completes, and returns
+// the completed job. It fails when the job fails, is flagged, is hidden by
+// moderators, or cannot be found.
+func (c *Client) WaitJob(
+	ctx context.Context,
+	jobID string,
+	opts *WaitOptions,
+) (*Job, error) {
+	if jobID == "" {
+		return nil, ErrJobIDRequired
+	}
+
+	jobs, err := c.WaitJobs(ctx, []string{jobID}, opts)
+	var be *BulkError
+	if errors.As(err, &be) {
+		err = be.Errors[jobID]
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs[0], nil
+}
+
+// WaitJobs waits for all given jobs to complete like WaitJob, looking up the
+// status of all pending jobs with a single request per check.
+//
+// Failed checks are retried as configured by WaitOptions.Retries, after which
+// all pending jobs fail with the error of the last check.
+//
+// The returned jobs are in the same order as jobIDs, with each job's last known
+// state, or nil if it was never found. If any job did not complete, a
+// *BulkError is returned listing them.
+func (c *Client) WaitJobs(
+	ctx context.Context,
+	jobIDs []string,
+	opts *WaitOptions,
+) ([]*Job, error) {
+	if len(jobIDs) == 0 {
+		return nil, ErrJobIDsRequired
+	}
+	opts = opts.withDefaults()
+
+	var (
+		latest  = map[string]*Job{}
+		errs    = map[string]error{}
+		pending = map[string]bool{}
+		missing = map[string]int{}
+	)
+	for _, id := range jobIDs {
+		if id == "" {
+			return nil, ErrJobIDRequired
+		}
+		pending[id] = true
+	}
+
+	interval := opts.Interval
+	failures := 0
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for len(pending) > 0 {
+		select {
+		case <-ctx.Done():
+			for id := range pending {
+				errs[id] = ctx.Err()
+			}
+			pending = nil
+
+			continue
+		case <-timer.C:
+		}
+
+		ids := make([]string, 0, len(pending))
+		for id := range pending {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		jobs, err := c.JobStatus(ctx, ids)
+		if err != nil {
+			failures++
+			if failures > opts.Retries {
+				for _, id := range ids {
+					errs[id] = err
+				}
+				pending = nil
+
+				continue
+			}
+
+			interval = opts.nextInterval(interval, false)
+			timer.Reset(interval)
+
+			continue
+		}
+		failures = 0
+
+		changed := false
+		found := make(map[string]bool, len(jobs))
+		for _, j := range jobs {
+			if j == nil || !pending[j.ID] {
+				continue
+			}
+			found[j.ID] = true
+			delete(missing, j.ID)
+			if prev := latest[j.ID]; prev == nil ||
+				prev.CurrentStatus != j.CurrentStatus {
+				changed = true
+			}
+			latest[j.ID] = j
+
+			if opts.Progress != nil {
+				opts.Progress(j)
+			}
+
+			if err := jobWaitError(j); err != nil {
+				errs[j.ID] = err
+				delete(pending, j.ID)
+			} else if j.CurrentStatus == JobStatusCompleted {
+				delete(pending, j.ID)
+			}
+		}
+
+		for _, id := range ids {
+			if found[id] {
+				continue
+			}
+			missing[id]++
+			if missing[id] >= opts.NotFoundChecks {
+				errs[id] = fmt.Errorf("%w: id=%s", ErrJobNotFound, id)
+				delete(pending, id)
+			}
+		}
+
+		interval = opts.nextInterval(interval, changed)
+		timer.Reset(interval)
+	}
+
+	result := make([]*Job, 0, len(jobIDs))
+	for _, id := range jobIDs {
+		result = append(result, latest[id])
+	}
+
+	if len(errs) == 0 {
+		return result, nil
+	}
+
+	succeeded := []string{}
+	for id := range latest {
+		if _, ok := errs[id]; !ok {
+			succeeded = append(succeeded, id)
+		}
+	}
+	sort.Strings(succeeded)
+
+	return result, &BulkError{Errors: errs, Succeeded: succeeded}
+}
+
+// jobWaitError returns the error to fail waiting on the given job with, or nil
+// if the job may still complete.
+func jobWaitError(j *Job) error {
+	switch {
+	case j.CurrentStatus == JobStatusFailed:
+		return fmt.Errorf("%w: id=%s", ErrJobFailed, j.ID)
+	case j.Flagged:
+		return fmt.Errorf("%w: id=%s", ErrJobFlagged, j.ID)
+	case j.ModHidden:
+		return &ModHiddenError{JobID: j.ID}
+	}
+
+	return nil
+}
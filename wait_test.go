@@ -0,0 +1,345 @@
+package midjourney
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fastWait() *WaitOptions {
+	return &WaitOptions{
+		Interval:    time.Millisecond,
+		MaxInterval: 2 * time.Millisecond,
+	}
+}
+
+// failingHandler responds with a server error to each request which fail
+// returns true for, counting from zero, and passes other requests to next.
+func failingHandler(next http.Handler, fail func(n int) bool) http.Handler {
+	var (
+		mux sync.Mutex
+		n   int
+	)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		i := n
+		n++
+		mux.Unlock()
+
+		if fail(i) {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestClient_WaitJob(t *testing.T) {
+	tests := []struct {
+		name    string
+		states  []*Job
+		want    JobStatus
+		wantErr error
+	}{
+		{
+			name: "completed",
+			states: []*Job{
+				{CurrentStatus: JobStatusQueued},
+				{CurrentStatus: JobStatusRunning},
+				{CurrentStatus: JobStatusCompleted},
+			},
+			want: JobStatusCompleted,
+		},
+		{
+			name: "failed",
+			states: []*Job{
+				{CurrentStatus: JobStatusRunning},
+				{CurrentStatus: JobStatusFailed},
+			},
+			wantErr: ErrJobFailed,
+		},
+		{
+			name: "flagged",
+			states: []*Job{
+				{CurrentStatus: JobStatusRunning, Flagged: true},
+			},
+			wantErr: ErrJobFlagged,
+		},
+		{
+			name: "mod hidden",
+			states: []*Job{
+				{CurrentStatus: JobStatusCompleted, ModHidden: true},
+			},
+			wantErr: ErrModHidden,
+		},
+		{
+			name:    "not found",
+			states:  nil,
+			wantErr: ErrJobNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &fakeJobStatus{}
+			srv.set("a", tt.states...)
+			c := newTestClient(t, srv)
+
+			seen := []JobStatus{}
+			opts := fastWait()
+			opts.Progress = func(j *Job) {
+				seen = append(seen, j.CurrentStatus)
+			}
+
+			got, err := c.WaitJob(context.Background(), "a", opts)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got.CurrentStatus)
+			}
+
+			want := []JobStatus{}
+			for _, j := range tt.states {
+				want = append(want, j.CurrentStatus)
+			}
+			assert.Equal(t, want, seen)
+		})
+	}
+}
+
+func TestClient_WaitJob_ModHidden(t *testing.T) {
+	srv := &fakeJobStatus{states: map[string][]*Job{
+		"a": {{CurrentStatus: JobStatusCompleted, ModHidden: true}},
+	}}
+	c := newTestClient(t, srv)
+
+	_, err := c.WaitJob(context.Background(), "a", fastWait())
+
+	var modErr *ModHiddenError
+	require.ErrorAs(t, err, &modErr)
+	assert.Equal(t, "a", modErr.JobID)
+	assert.EqualError(t, err, "midjourney: job hidden by moderators: id=a")
+}
+
+func TestClient_WaitJob_Canceled(t *testing.T) {
+	srv := &fakeJobStatus{states: map[string][]*Job{
+		"a": {{CurrentStatus: JobStatusRunning}},
+	}}
+	c := newTestClient(t, srv)
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), 20*time.Millisecond,
+	)
+	defer cancel()
+
+	_, err := c.WaitJob(ctx, "a", fastWait())
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_WaitJobs(t *testing.T) {
+	srv := &fakeJobStatus{states: map[string][]*Job{
+		"a": {
+			{CurrentStatus: JobStatusRunning},
+			{CurrentStatus: JobStatusCompleted},
+		},
+		"b": {
+			{CurrentStatus: JobStatusRunning},
+			{CurrentStatus: JobStatusRunning},
+			{CurrentStatus: JobStatusFailed},
+		},
+		"c": {{CurrentStatus: JobStatusCompleted}},
+	}}
+	c := newTestClient(t, srv)
+
+	jobs, err := c.WaitJobs(
+		context.Background(), []string{"c", "b", "a"}, fastWait(),
+	)
+
+	var be *BulkError
+	require.True(t, errors.As(err, &be))
+	assert.Equal(t, []string{"b"}, be.IDs())
+	assert.Equal(t, []string{"a", "c"}, be.Succeeded)
+	assert.ErrorIs(t, err, ErrJobFailed)
+
+	require.Len(t, jobs, 3)
+	assert.Equal(t, "c", jobs[0].ID)
+	assert.Equal(t, JobStatusFailed, jobs[1].CurrentStatus)
+	assert.Equal(t, JobStatusCompleted, jobs[2].CurrentStatus)
+
+	assert.Equal(t, [][]string{
+		{"a", "b", "c"},
+		{"a", "b"},
+		{"b"},
+	}, srv.requests)
+}
+
+func TestClient_WaitJobs_NotFound(t *testing.T) {
+	srv := &fakeJobStatus{states: map[string][]*Job{
+		"a": {
+			{CurrentStatus: JobStatusRunning},
+			{CurrentStatus: JobStatusCompleted},
+		},
+	}}
+	c := newTestClient(t, srv)
+
+	jobs, err := c.WaitJobs(
+		context.Background(), []string{"a", "missing"}, fastWait(),
+	)
+
+	var be *BulkError
+	require.True(t, errors.As(err, &be))
+	assert.Equal(t, []string{"missing"}, be.IDs())
+	assert.Equal(t, []string{"a"}, be.Succeeded)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+
+	require.Len(t, jobs, 2)
+	assert.Equal(t, JobStatusCompleted, jobs[0].CurrentStatus)
+	assert.Nil(t, jobs[1])
+
+	assert.Equal(t, [][]string{
+		{"a", "missing"},
+		{"a", "missing"},
+		{"missing"},
+	}, srv.requests)
+}
+
+func TestClient_WaitJobs_FoundLate(t *testing.T) {
+	srv := &fakeJobStatus{}
+	var once sync.Once
+	c := newTestClient(t, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			srv.ServeHTTP(w, r)
+			once.Do(func() {
+				srv.set("a",
+					&Job{CurrentStatus: JobStatusRunning},
+					&Job{CurrentStatus: JobStatusCompleted},
+				)
+			})
+		},
+	))
+
+	jobs, err := c.WaitJobs(context.Background(), []string{"a"}, fastWait())
+	require.NoError(t, err)
+
+	require.Len(t, jobs, 1)
+	assert.Equal(t, JobStatusCompleted, jobs[0].CurrentStatus)
+	assert.Len(t, srv.requests, 3)
+}
+
+func TestClient_WaitJobs_RetriesFailedChecks(t *testing.T) {
+	srv := &fakeJobStatus{states: map[string][]*Job{
+		"a": {
+			{CurrentStatus: JobStatusRunning},
+			{CurrentStatus: JobStatusCompleted},
+		},
+	}}
+	c := newTestClient(t, failingHandler(srv, func(n int) bool {
+		return n == 1 || n == 2
+	}))
+
+	jobs, err := c.WaitJobs(context.Background(), []string{"a"}, fastWait())
+	require.NoError(t, err)
+
+	require.Len(t, jobs, 1)
+	assert.Equal(t, JobStatusCompleted, jobs[0].CurrentStatus)
+	assert.Equal(t, [][]string{{"a"}, {"a"}}, srv.requests)
+}
+
+func TestClient_WaitJobs_TooManyFailedChecks(t *testing.T) {
+	srv := &fakeJobStatus{states: map[string][]*Job{
+		"a": {{CurrentStatus: JobStatusCompleted}},
+		"b": {{CurrentStatus: JobStatusRunning}},
+	}}
+	c := newTestClient(t, failingHandler(srv, func(n int) bool {
+		return n > 0
+	}))
+
+	opts := fastWait()
+	opts.Retries = 2
+	jobs, err := c.WaitJobs(context.Background(), []string{"a", "b"}, opts)
+
+	var be *BulkError
+	require.True(t, errors.As(err, &be))
+	assert.Equal(t, []string{"b"}, be.IDs())
+	assert.Equal(t, []string{"a"}, be.Succeeded)
+	assert.ErrorIs(t, err, ErrResponseStatus)
+
+	require.Len(t, jobs, 2)
+	assert.Equal(t, JobStatusCompleted, jobs[0].CurrentStatus)
+	assert.Equal(t, JobStatusRunning, jobs[1].CurrentStatus)
+}
+
+func TestClient_WaitJobs_JobIDsRequired(t *testing.T) {
+	c, err := New()
+	require.NoError(t, err)
+
+	_, err = c.WaitJobs(context.Background(), nil, nil)
+
+	assert.ErrorIs(t, err, ErrJobIDsRequired)
+}
+
+func TestWaitOptions_withDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *WaitOptions
+		want *WaitOptions
+	}{
+		{
+			name: "nil",
+			opts: nil,
+			want: &WaitOptions{
+				Interval:       DefaultPollInterval,
+				MaxInterval:    DefaultMaxPollInterval,
+				Backoff:        DefaultPollBackoff,
+				Retries:        DefaultPollRetries,
+				NotFoundChecks: DefaultNotFoundChecks,
+			},
+		},
+		{
+			name: "interval above default max",
+			opts: &WaitOptions{Interval: time.Minute, Backoff: 2},
+			want: &WaitOptions{
+				Interval:       time.Minute,
+				MaxInterval:    time.Minute,
+				Backoff:        2,
+				Retries:        DefaultPollRetries,
+				NotFoundChecks: DefaultNotFoundChecks,
+			},
+		},
+		{
+			name: "custom",
+			opts: &WaitOptions{
+				Interval:       time.Second,
+				MaxInterval:    10 * time.Second,
+				Backoff:        0.5,
+				Retries:        1,
+				NotFoundChecks: 1,
+			},
+			want: &WaitOptions{
+				Interval:       time.Second,
+				MaxInterval:    10 * time.Second,
+				Backoff:        DefaultPollBackoff,
+				Retries:        1,
+				NotFoundChecks: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.opts.withDefaults()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,9 +3,9 @@ package midjourney
 import (
 	"context"
 	"crypto/rand"
-	"fmt"
 	"math/big"
 	"net/url"
+	"sort"
 	"strconv"
 )
 
@@ -15,7 +15,7 @@ type Word struct {
 }
 
 func (w *Word) ImageURL() string {
-	return fmt.Sprintf("https://i.mj.run/%s/0_0.png", w.ImageID)
+	return DefaultAssets.WordImageURL(w)
 }
 
 type WordsQuery struct {
@@ -59,6 +59,8 @@ func randInt(max int) int {
 	return int(r.Int64())
 }
 
+// Words returns a single page of words matching q, sorted alphabetically. Use
+// WordsIter to fetch all pages.
 func (c *Client) Words(ctx context.Context, q *WordsQuery) ([]*Word, error) {
 	w := map[string]string{}
 	err := c.API.Get(ctx, "app/words/", q.URLValues(), &w)
@@ -73,6 +75,9 @@ func (c *Client) Words(ctx context.Context, q *WordsQuery) ([]*Word, error) {
 			ImageID: imageID,
 		})
 	}
+	sort.Slice(words, func(i, j int) bool {
+		return words[i].Word < words[j].Word
+	})
 
 	return words, nil
 }
@@ -0,0 +1,254 @@
+package midjourney
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jimeh/go-midjourney/internal/fileutil"
+)
+
+// DefaultWordsAmount is the page size used by WordsIter when the query does
+// not specify an amount.
+const DefaultWordsAmount = 50
+
+// WordsIter iterates over all pages of words matching a WordsQuery. The seed
+// is pinned before the first page is fetched, so pages are consistent with
+// each other, and the same seed yields the same words in the same order. Words
+// which reappear on later pages are skipped.
+//
+//	it := client.WordsIter(query)
+//	for it.Next(ctx) {
+//		word := it.Word()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type WordsIter struct {
+	client *Client
+	query  *WordsQuery
+	page   []*Word
+	word   *Word
+	seen   map[string]bool
+	last   bool
+	err    error
+}
+
+// WordsIter returns an iterator over all pages of words matching q. If q does
+// not specify a seed, or sets RandomSeed, a random seed is picked and used for
+// all pages.
+func (c *Client) WordsIter(q *WordsQuery) *WordsIter {
+	qc := *q
+	if qc.RandomSeed || qc.Seed == 0 {
+		qc.Seed = randInt(9999) + 1
+		qc.RandomSeed = false
+	}
+	if qc.Amount <= 0 {
+		qc.Amount = DefaultWordsAmount
+	}
+
+	return &WordsIter{client: c, query: &qc, seen: map[string]bool{}}
+}
+
+// Seed returns the seed used for all pages.
+func (it *WordsIter) Seed() int {
+	return it.query.Seed
+}
+
+// Next advances the iterator to the next word, fetching the next page if
+// needed. It returns false when there are no more words, or an error occurred.
+func (it *WordsIter) Next(ctx context.Context) bool {
+	for it.err == nil {
+		for len(it.page) > 0 {
+			w := it.page[0]
+			it.page = it.page[1:]
+
+			if w == nil || it.seen[w.Word] {
+				continue
+			}
+			it.seen[w.Word] = true
+			it.word = w
+
+			return true
+		}
+
+		if it.last {
+			break
+		}
+		it.fetch(ctx)
+	}
+	it.word = nil
+
+	return false
+}
+
+func (it *WordsIter) fetch(ctx context.Context) {
+	words, err := it.client.Words(ctx, it.query)
+	if err != nil {
+		it.err = err
+
+		return
+	}
+
+	// Stop when a page has no new words, in case the API keeps returning the
+	// same words for any page number.
+	fresh := 0
+	for _, w := range words {
+		if !it.seen[w.Word] {
+			fresh++
+		}
+	}
+
+	it.page = words
+	it.last = fresh == 0 || len(words) < it.query.Amount
+	it.query.Page++
+}
+
+// Word returns the current word.
+func (it *WordsIter) Word() *Word {
+	return it.word
+}
+
+// Err returns the first error encountered while iterating.
+func (it *WordsIter) Err() error {
+	return it.err
+}
+
+// Vocabulary is a complete list of words and their image IDs, as fetched by
+// Client.Vocabulary, which can be saved for offline browsing.
+type Vocabulary struct {
+	Query string
+	Seed  int
+	Words []*Word
+}
+
+// vocabularyJSON is the JSON representation of a Vocabulary.
+type vocabularyJSON struct {
+	Query string                `json:"query,omitempty"`
+	Seed  int                   `json:"seed"`
+	Words []*vocabularyWordJSON `json:"words"`
+}
+
+// vocabularyWordJSON is the JSON representation of a Word within a
+// Vocabulary.
+type vocabularyWordJSON struct {
+	Word    string `json:"word"`
+	ImageID string `json:"image_id"`
+}
+
+// Vocabulary fetches all pages of words matching q, and returns them sorted
+// alphabetically.
+func (c *Client) Vocabulary(
+	ctx context.Context,
+	q *WordsQuery,
+) (*Vocabulary, error) {
+	it := c.WordsIter(q)
+	v := &Vocabulary{Query: q.Query, Seed: it.Seed(), Words: []*Word{}}
+	for it.Next(ctx) {
+		v.Words = append(v.Words, it.Word())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	v.sort()
+
+	return v, nil
+}
+
+func (v *Vocabulary) sort() {
+	sort.Slice(v.Words, func(i, j int) bool {
+		return v.Words[i].Word < v.Words[j].Word
+	})
+}
+
+// Word returns the given word, or nil if it is not part of the vocabulary.
+func (v *Vocabulary) Word(word string) *Word {
+	i := sort.Search(len(v.Words), func(i int) bool {
+		return v.Words[i].Word >= word
+	})
+	if i < len(v.Words) && v.Words[i].Word == word {
+		return v.Words[i]
+	}
+
+	return nil
+}
+
+// Find returns all words containing s, ignoring case.
+func (v *Vocabulary) Find(s string) []*Word {
+	s = strings.ToLower(s)
+	words := []*Word{}
+	for _, w := range v.Words {
+		if strings.Contains(strings.ToLower(w.Word), s) {
+			words = append(words, w)
+		}
+	}
+
+	return words
+}
+
+func (v *Vocabulary) MarshalJSON() ([]byte, error) {
+	vj := &vocabularyJSON{
+		Query: v.Query,
+		Seed:  v.Seed,
+		Words: make([]*vocabularyWordJSON, 0, len(v.Words)),
+	}
+	for _, w := range v.Words {
+		vj.Words = append(vj.Words, &vocabularyWordJSON{
+			Word:    w.Word,
+			ImageID: w.ImageID,
+		})
+	}
+
+	return json.Marshal(vj)
+}
+
+func (v *Vocabulary) UnmarshalJSON(b []byte) error {
+	vj := &vocabularyJSON{}
+	err := json.Unmarshal(b, vj)
+	if err != nil {
+		return err
+	}
+
+	v.Query = vj.Query
+	v.Seed = vj.Seed
+	v.Words = make([]*Word, 0, len(vj.Words))
+	for _, w := range vj.Words {
+		if w != nil {
+			v.Words = append(v.Words, &Word{Word: w.Word, ImageID: w.ImageID})
+		}
+	}
+
+	return nil
+}
+
+// Save writes the vocabulary to w as JSON.
+func (v *Vocabulary) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
+
+// LoadVocabulary reads a vocabulary previously written with Save.
+func LoadVocabulary(r io.Reader) (*Vocabulary, error) {
+	v := &Vocabulary{}
+	err := json.NewDecoder(r).Decode(v)
+	if err != nil {
+		return nil, err
+	}
+	v.sort()
+
+	return v, nil
+}
+
+// SaveFile writes the vocabulary to the given file, replacing it atomically.
+func (v *Vocabulary) SaveFile(path string) error {
+	return fileutil.WriteAtomic(path, v.Save)
+}
+
+// OpenVocabulary loads a vocabulary from the given file.
+func OpenVocabulary(path string) (*Vocabulary, error) {
+	return fileutil.Load(path, LoadVocabulary)
+}
@@ -0,0 +1,180 @@
+package midjourney
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWordsServer implements app/words/, returning pages of a vocabulary
+// shuffled by seed. Each page after the first repeats the last word of the
+// previous page.
+type fakeWordsServer struct {
+	*fakeServer
+	size       int
+	ignorePage bool
+	queries    []*WordsQuery
+}
+
+func newFakeWordsServer(size int, ignorePage bool) *fakeWordsServer {
+	s := &fakeWordsServer{
+		fakeServer: newFakeServer(),
+		size:       size,
+		ignorePage: ignorePage,
+	}
+	s.handle("/app/words/", s.words)
+
+	return s
+}
+
+func (s *fakeWordsServer) words(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	q := &WordsQuery{Query: params.Get("query")}
+	q.Amount, _ = strconv.Atoi(params.Get("amount"))
+	q.Page, _ = strconv.Atoi(params.Get("page"))
+	q.Seed, _ = strconv.Atoi(params.Get("seed"))
+	s.queries = append(s.queries, q)
+
+	vocab := make([]string, 0, s.size)
+	for i := 0; i < s.size; i++ {
+		vocab = append(vocab, fmt.Sprintf("word%03d", i))
+	}
+	rnd := rand.New(rand.NewSource(int64(q.Seed))) //nolint:gosec
+	rnd.Shuffle(len(vocab), func(i, j int) {
+		vocab[i], vocab[j] = vocab[j], vocab[i]
+	})
+
+	page := q.Page
+	if s.ignorePage {
+		page = 0
+	}
+	start := page*q.Amount - page
+	end := start + q.Amount
+	if start > len(vocab) {
+		start = len(vocab)
+	}
+	if end > len(vocab) {
+		end = len(vocab)
+	}
+
+	resp := map[string]string{}
+	for _, word := range vocab[start:end] {
+		resp[word] = "img-" + word
+	}
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func collectWords(t *testing.T, it *WordsIter) []string {
+	t.Helper()
+
+	words := []string{}
+	for it.Next(context.Background()) {
+		words = append(words, it.Word().Word)
+		assert.Equal(t, "img-"+it.Word().Word, it.Word().ImageID)
+	}
+	require.NoError(t, it.Err())
+
+	return words
+}
+
+func TestClient_WordsIter(t *testing.T) {
+	srv := newFakeWordsServer(100, false)
+	c := newTestClient(t, srv)
+
+	words := collectWords(t, c.WordsIter(&WordsQuery{Amount: 30, Seed: 42}))
+
+	assert.Len(t, words, 100)
+	seen := map[string]bool{}
+	for _, w := range words {
+		assert.False(t, seen[w], "duplicate word %s", w)
+		seen[w] = true
+	}
+
+	require.Len(t, srv.queries, 4)
+	for i, q := range srv.queries {
+		assert.Equal(t, i, q.Page)
+		assert.Equal(t, 42, q.Seed)
+		assert.Equal(t, 30, q.Amount)
+	}
+
+	again := collectWords(t, c.WordsIter(&WordsQuery{Amount: 30, Seed: 42}))
+	assert.Equal(t, words, again)
+}
+
+func TestClient_WordsIter_PinsRandomSeed(t *testing.T) {
+	srv := newFakeWordsServer(120, false)
+	c := newTestClient(t, srv)
+
+	it := c.WordsIter(&WordsQuery{RandomSeed: true})
+	words := collectWords(t, it)
+
+	assert.Len(t, words, 120)
+	assert.Greater(t, it.Seed(), 0)
+	require.Len(t, srv.queries, 3)
+	for _, q := range srv.queries {
+		assert.Equal(t, it.Seed(), q.Seed)
+		assert.Equal(t, DefaultWordsAmount, q.Amount)
+	}
+}
+
+func TestClient_WordsIter_StopsOnRepeatedPage(t *testing.T) {
+	srv := newFakeWordsServer(100, true)
+	c := newTestClient(t, srv)
+
+	words := collectWords(t, c.WordsIter(&WordsQuery{Amount: 10, Seed: 1}))
+
+	assert.Len(t, words, 10)
+	assert.Len(t, srv.queries, 2)
+}
+
+func TestClient_Vocabulary(t *testing.T) {
+	srv := newFakeWordsServer(60, false)
+	c := newTestClient(t, srv)
+
+	v, err := c.Vocabulary(
+		context.Background(), &WordsQuery{Query: "w", Amount: 25, Seed: 7},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "w", v.Query)
+	assert.Equal(t, 7, v.Seed)
+	require.Len(t, v.Words, 60)
+	assert.Equal(t, "word000", v.Words[0].Word)
+	assert.Equal(t, "word059", v.Words[59].Word)
+
+	assert.Equal(t, &Word{Word: "word012", ImageID: "img-word012"},
+		v.Word("word012"),
+	)
+	assert.Nil(t, v.Word("word999"))
+	assert.Len(t, v.Find("WORD01"), 10)
+
+	var buf bytes.Buffer
+	require.NoError(t, v.Save(&buf))
+	assert.Contains(t, buf.String(), `"image_id": "img-word000"`)
+
+	b, err := json.Marshal(v.Words[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Word":"word000","ImageID":"img-word000"}`, string(b),
+		"Word's own JSON format should not change",
+	)
+
+	got, err := LoadVocabulary(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, v, got)
+
+	path := filepath.Join(t.TempDir(), "words.json")
+	require.NoError(t, v.SaveFile(path))
+	got, err = OpenVocabulary(path)
+	require.NoError(t, err)
+	assert.Equal(t, v, got)
+}